@@ -472,6 +472,16 @@ func (s *CmtRPCService) QueryValidator(address common.Address, height uint64) (*
 	return &StakeQueryResult{h, &candidate}, nil
 }
 
+func (s *CmtRPCService) QueryValidatorVerification(address common.Address, height uint64) (*StakeQueryResult, error) {
+	var signOffs []*stake.VerificationSignOff
+	h, err := s.getParsedFromJson("/validator/verification", []byte(address.Hex()), &signOffs, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, signOffs}, nil
+}
+
 type GovernanceTransferFundProposalArgs struct {
 	Nonce             *hexutil.Uint64 `json:"nonce"`
 	From              common.Address  `json:"from"`
@@ -481,12 +491,16 @@ type GovernanceTransferFundProposalArgs struct {
 	Reason            string          `json:"reason"`
 	ExpireTimestamp   *int64          `json:"expireTimestamp"`
 	ExpireBlockHeight *int64          `json:"expireBlockHeight"`
+	// either of them delays the transfer after the proposal is approved
+	ExecutionDelay        *int64 `json:"executionDelay"`
+	ActivationBlockHeight *int64 `json:"activationBlockHeight"`
 }
 
 func (s *CmtRPCService) ProposeTransferFund(args GovernanceTransferFundProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
 	tx := governance.NewTxTransferFundPropose(&args.TransferFrom, &args.TransferTo,
 		args.Amount.ToInt().String(), args.Reason,
-		args.ExpireTimestamp, args.ExpireBlockHeight)
+		args.ExpireTimestamp, args.ExpireBlockHeight,
+		args.ExecutionDelay, args.ActivationBlockHeight)
 
 	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
 	if err != nil {
@@ -497,18 +511,28 @@ func (s *CmtRPCService) ProposeTransferFund(args GovernanceTransferFundProposalA
 }
 
 type GovernanceChangeParamProposalArgs struct {
-	Nonce             *hexutil.Uint64 `json:"nonce"`
-	From              common.Address  `json:"from"`
-	Name              string          `json:"name"`
-	Value             string          `json:"value"`
-	Reason            string          `json:"reason"`
-	ExpireTimestamp   *int64          `json:"expireTimestamp"`
-	ExpireBlockHeight *int64          `json:"expireBlockHeight"`
+	Nonce             *hexutil.Uint64          `json:"nonce"`
+	From              common.Address           `json:"from"`
+	Name              string                   `json:"name"`
+	Value             string                   `json:"value"`
+	Params            []governance.ParamChange `json:"params"`
+	Reason            string                   `json:"reason"`
+	ExpireTimestamp   *int64                   `json:"expireTimestamp"`
+	ExpireBlockHeight *int64                   `json:"expireBlockHeight"`
+	// either of them delays the param changes after the proposal is approved
+	ExecutionDelay        *int64 `json:"executionDelay"`
+	ActivationBlockHeight *int64 `json:"activationBlockHeight"`
 }
 
 func (s *CmtRPCService) ProposeChangeParam(args GovernanceChangeParamProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
 	tx := governance.NewTxChangeParamPropose(args.Name, args.Value, args.Reason,
-		args.ExpireTimestamp, args.ExpireBlockHeight)
+		args.ExpireTimestamp, args.ExpireBlockHeight,
+		args.ExecutionDelay, args.ActivationBlockHeight)
+	if len(args.Params) > 0 {
+		tx = governance.NewTxChangeParamsPropose(args.Params, args.Reason,
+			args.ExpireTimestamp, args.ExpireBlockHeight,
+			args.ExecutionDelay, args.ActivationBlockHeight)
+	}
 
 	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
 	if err != nil {
@@ -525,13 +549,15 @@ type GovernanceDeployLibEniProposalArgs struct {
 	Version           string          `json:"version"`
 	FileUrl           string          `json:"fileUrl"`
 	Md5               string          `json:"md5"`
+	Sha256            string          `json:"sha256"`
+	Signature         string          `json:"signature"`
 	Reason            string          `json:"reason"`
 	DeployTimestamp   *int64          `json:"deployTimestamp"`
 	DeployBlockHeight *int64          `json:"deployBlockHeight"`
 }
 
 func (s *CmtRPCService) ProposeDeployLibEni(args GovernanceDeployLibEniProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
-	tx := governance.NewTxDeployLibEniPropose(args.Name, args.Version, args.FileUrl, args.Md5, args.Reason,
+	tx := governance.NewTxDeployLibEniPropose(args.Name, args.Version, args.FileUrl, args.Md5, args.Sha256, args.Signature, args.Reason,
 		args.DeployTimestamp, args.DeployBlockHeight)
 
 	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
@@ -568,13 +594,231 @@ type GovernanceUpgradeProgramProposalArgs struct {
 	Version            string          `json:"version"`
 	FileUrl            string          `json:"fileUrl"`
 	Md5                string          `json:"md5"`
+	Sha256             string          `json:"sha256"`
+	Signature          string          `json:"signature"`
 	Reason             string          `json:"reason"`
 	UpgradeBlockHeight *int64          `json:"upgradeBlockHeight"`
 }
 
 func (s *CmtRPCService) ProposeUpgradeProgram(args GovernanceUpgradeProgramProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
 	tx := governance.NewTxUpgradeProgramPropose(args.Name,
-		args.Version, args.FileUrl, args.Md5, args.Reason, args.UpgradeBlockHeight)
+		args.Version, args.FileUrl, args.Md5, args.Sha256, args.Signature, args.Reason, args.UpgradeBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type GovernanceTextProposalArgs struct {
+	Nonce             *hexutil.Uint64 `json:"nonce"`
+	From              common.Address  `json:"from"`
+	Title             string          `json:"title"`
+	Description       string          `json:"description"`
+	DocumentUrl       string          `json:"documentUrl"`
+	DocumentHash      string          `json:"documentHash"`
+	ExpireTimestamp   *int64          `json:"expireTimestamp"`
+	ExpireBlockHeight *int64          `json:"expireBlockHeight"`
+}
+
+func (s *CmtRPCService) ProposeText(args GovernanceTextProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxTextPropose(args.Title, args.Description, args.DocumentUrl, args.DocumentHash,
+		args.ExpireTimestamp, args.ExpireBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type GovernanceValidatorProposalArgs struct {
+	Nonce             *hexutil.Uint64 `json:"nonce"`
+	From              common.Address  `json:"from"`
+	CandidateAddress  common.Address  `json:"candidateAddress"`
+	Reason            string          `json:"reason"`
+	ExpireTimestamp   *int64          `json:"expireTimestamp"`
+	ExpireBlockHeight *int64          `json:"expireBlockHeight"`
+}
+
+func (s *CmtRPCService) ProposeAddValidator(args GovernanceValidatorProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxAddValidatorPropose(&args.CandidateAddress, args.Reason, args.ExpireTimestamp, args.ExpireBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+func (s *CmtRPCService) ProposeRemoveValidator(args GovernanceValidatorProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxRemoveValidatorPropose(&args.CandidateAddress, args.Reason, args.ExpireTimestamp, args.ExpireBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type GovernanceGrantProposalArgs struct {
+	Nonce     *hexutil.Uint64 `json:"nonce"`
+	From      common.Address  `json:"from"`
+	GrantFrom common.Address  `json:"grantFrom"`
+	GrantTo   common.Address  `json:"grantTo"`
+	Amount    hexutil.Big     `json:"amount"`
+	// the amount is paid out in tranches, one every interval blocks
+	Tranches          int64  `json:"tranches"`
+	Interval          int64  `json:"interval"`
+	Reason            string `json:"reason"`
+	ExpireTimestamp   *int64 `json:"expireTimestamp"`
+	ExpireBlockHeight *int64 `json:"expireBlockHeight"`
+}
+
+func (s *CmtRPCService) ProposeGrant(args GovernanceGrantProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxGrantPropose(&args.GrantFrom, &args.GrantTo,
+		args.Amount.ToInt().String(), args.Tranches, args.Interval, args.Reason,
+		args.ExpireTimestamp, args.ExpireBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type GovernanceCancelGrantProposalArgs struct {
+	Nonce             *hexutil.Uint64 `json:"nonce"`
+	From              common.Address  `json:"from"`
+	GrantId           string          `json:"grantId"`
+	Reason            string          `json:"reason"`
+	ExpireTimestamp   *int64          `json:"expireTimestamp"`
+	ExpireBlockHeight *int64          `json:"expireBlockHeight"`
+}
+
+func (s *CmtRPCService) ProposeCancelGrant(args GovernanceCancelGrantProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxCancelGrantPropose(args.GrantId, args.Reason, args.ExpireTimestamp, args.ExpireBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type GovernanceContractCallProposalArgs struct {
+	Nonce             *hexutil.Uint64 `json:"nonce"`
+	From              common.Address  `json:"from"`
+	To                common.Address  `json:"to"`
+	Value             hexutil.Big     `json:"value"`
+	Data              hexutil.Bytes   `json:"data"`
+	Gas               hexutil.Uint64  `json:"gas"`
+	Reason            string          `json:"reason"`
+	ExpireTimestamp   *int64          `json:"expireTimestamp"`
+	ExpireBlockHeight *int64          `json:"expireBlockHeight"`
+	// either of them delays the call after the proposal is approved
+	ExecutionDelay        *int64 `json:"executionDelay"`
+	ActivationBlockHeight *int64 `json:"activationBlockHeight"`
+}
+
+// ProposeContractCall proposes to call a contract from the governance account, the value is paid from its balance
+func (s *CmtRPCService) ProposeContractCall(args GovernanceContractCallProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	var data string
+	if len(args.Data) > 0 {
+		data = args.Data.String()
+	}
+	tx := governance.NewTxContractCallPropose(&args.To, args.Value.ToInt().String(), data, uint64(args.Gas), args.Reason,
+		args.ExpireTimestamp, args.ExpireBlockHeight,
+		args.ExecutionDelay, args.ActivationBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type GovernanceEmergencyPauseProposalArgs struct {
+	Nonce *hexutil.Uint64 `json:"nonce"`
+	From  common.Address  `json:"from"`
+	// only the calls to the contracts are paused if any is given
+	Contracts []common.Address `json:"contracts"`
+	// number of blocks the pause lasts, defaults to the emergency_pause_duration param if 0
+	Duration          int64  `json:"duration"`
+	Reason            string `json:"reason"`
+	ExpireTimestamp   *int64 `json:"expireTimestamp"`
+	ExpireBlockHeight *int64 `json:"expireBlockHeight"`
+}
+
+func (s *CmtRPCService) ProposeEmergencyPause(args GovernanceEmergencyPauseProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxEmergencyPausePropose(args.Contracts, args.Duration, args.Reason, args.ExpireTimestamp, args.ExpireBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type GovernanceUnpauseProposalArgs struct {
+	Nonce             *hexutil.Uint64 `json:"nonce"`
+	From              common.Address  `json:"from"`
+	Reason            string          `json:"reason"`
+	ExpireTimestamp   *int64          `json:"expireTimestamp"`
+	ExpireBlockHeight *int64          `json:"expireBlockHeight"`
+}
+
+func (s *CmtRPCService) ProposeUnpause(args GovernanceUnpauseProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxUnpausePropose(args.Reason, args.ExpireTimestamp, args.ExpireBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type GovernanceConsensusParamsProposalArgs struct {
+	Nonce *hexutil.Uint64 `json:"nonce"`
+	From  common.Address  `json:"from"`
+	// the params left empty are unchanged
+	BlockMaxBytes      *int64 `json:"blockMaxBytes"`
+	BlockMaxTxs        *int64 `json:"blockMaxTxs"`
+	BlockMaxGas        *int64 `json:"blockMaxGas"`
+	TxMaxBytes         *int64 `json:"txMaxBytes"`
+	TxMaxGas           *int64 `json:"txMaxGas"`
+	BlockPartSizeBytes *int64 `json:"blockPartSizeBytes"`
+	Reason             string `json:"reason"`
+	ExpireTimestamp    *int64 `json:"expireTimestamp"`
+	ExpireBlockHeight  *int64 `json:"expireBlockHeight"`
+	// either of them delays the update after the proposal is approved
+	ExecutionDelay        *int64 `json:"executionDelay"`
+	ActivationBlockHeight *int64 `json:"activationBlockHeight"`
+}
+
+func (s *CmtRPCService) ProposeConsensusParams(args GovernanceConsensusParamsProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	content := &governance.ConsensusParamsContent{
+		BlockMaxBytes:      args.BlockMaxBytes,
+		BlockMaxTxs:        args.BlockMaxTxs,
+		BlockMaxGas:        args.BlockMaxGas,
+		TxMaxBytes:         args.TxMaxBytes,
+		TxMaxGas:           args.TxMaxGas,
+		BlockPartSizeBytes: args.BlockPartSizeBytes,
+		Reason:             args.Reason,
+	}
+	tx := governance.NewTxConsensusParamsPropose(content,
+		args.ExpireTimestamp, args.ExpireBlockHeight,
+		args.ExecutionDelay, args.ActivationBlockHeight)
 
 	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
 	if err != nil {
@@ -602,6 +846,66 @@ func (s *CmtRPCService) Vote(args GovernanceVoteArgs) (*ctypes.ResultBroadcastTx
 	return s.signAndBroadcastTxCommit(txArgs)
 }
 
+type GovernanceCancelProposalArgs struct {
+	Nonce      *hexutil.Uint64 `json:"nonce"`
+	From       common.Address  `json:"from"`
+	ProposalId string          `json:"proposalId"`
+}
+
+func (s *CmtRPCService) CancelProposal(args GovernanceCancelProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxCancelProposal(args.ProposalId)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type GovernanceDelegateVoteArgs struct {
+	Nonce     *hexutil.Uint64 `json:"nonce"`
+	From      common.Address  `json:"from"`
+	Delegatee common.Address  `json:"delegatee"`
+}
+
+func (s *CmtRPCService) DelegateVote(args GovernanceDelegateVoteArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxDelegateVote(&args.Delegatee)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type GovernanceRevokeVoteDelegationArgs struct {
+	Nonce *hexutil.Uint64 `json:"nonce"`
+	From  common.Address  `json:"from"`
+}
+
+func (s *CmtRPCService) RevokeVoteDelegation(args GovernanceRevokeVoteDelegationArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxRevokeVoteDelegation()
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+func (s *CmtRPCService) QueryVoteDelegations() (*StakeQueryResult, error) {
+	var delegations []*governance.VoteDelegation
+	h, err := s.getParsedFromJson("/governance/delegations", []byte{0}, &delegations, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, delegations}, nil
+}
+
 func (s *CmtRPCService) QueryProposals() (*StakeQueryResult, error) {
 	var proposals []*governance.Proposal
 	h, err := s.getParsedFromJson("/governance/proposals", []byte{0}, &proposals, 0)
@@ -612,6 +916,179 @@ func (s *CmtRPCService) QueryProposals() (*StakeQueryResult, error) {
 	return &StakeQueryResult{h, proposals}, nil
 }
 
+func (s *CmtRPCService) QueryProposal(pid string) (*StakeQueryResult, error) {
+	var proposal governance.Proposal
+	h, err := s.getParsedFromJson("/governance/proposal", []byte(pid), &proposal, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, &proposal}, nil
+}
+
+// QueryProposalsByFilter returns a page of the proposals matching the type, status, proposer and height range
+func (s *CmtRPCService) QueryProposalsByFilter(filter governance.ProposalFilter) (*StakeQueryResult, error) {
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var page governance.ProposalPage
+	h, err := s.getParsedFromJson("/governance/proposals/filter", data, &page, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, page}, nil
+}
+
+func (s *CmtRPCService) QueryVotes(pid string) (*StakeQueryResult, error) {
+	var votes []*governance.Vote
+	h, err := s.getParsedFromJson("/governance/votes", []byte(pid), &votes, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, votes}, nil
+}
+
+func (s *CmtRPCService) QueryVotesByVoter(voter common.Address) (*StakeQueryResult, error) {
+	var votes []*governance.Vote
+	h, err := s.getParsedFromJson("/governance/votes/voter", []byte(voter.Hex()), &votes, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, votes}, nil
+}
+
+// QueryProposalSnapshot returns the validators and their voting power the votes on the proposal are tallied against
+func (s *CmtRPCService) QueryProposalSnapshot(pid string) (*StakeQueryResult, error) {
+	var validators []*governance.SnapshotValidator
+	h, err := s.getParsedFromJson("/governance/snapshot", []byte(pid), &validators, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, validators}, nil
+}
+
+// QueryProposalEvents returns the log of the state transitions of a proposal, oldest first
+func (s *CmtRPCService) QueryProposalEvents(pid string) (*StakeQueryResult, error) {
+	var events []*governance.Event
+	h, err := s.getParsedFromJson("/governance/events", []byte(pid), &events, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, events}, nil
+}
+
+func (s *CmtRPCService) QueryProposalTally(pid string) (*StakeQueryResult, error) {
+	var tally governance.Tally
+	h, err := s.getParsedFromJson("/governance/tally", []byte(pid), &tally, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, tally}, nil
+}
+
+// QueryGrant returns the payout state of the grant approved by the proposal
+func (s *CmtRPCService) QueryGrant(pid string) (*StakeQueryResult, error) {
+	var grant governance.Grant
+	h, err := s.getParsedFromJson("/governance/grant", []byte(pid), &grant, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, &grant}, nil
+}
+
+// QueryGrants returns the grants with the status, e.g. Active, or all of them if the status is empty
+func (s *CmtRPCService) QueryGrants(status string) (*StakeQueryResult, error) {
+	data := []byte{0}
+	if status != "" {
+		data = []byte(status)
+	}
+
+	var grants []*governance.Grant
+	h, err := s.getParsedFromJson("/governance/grants", data, &grants, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, grants}, nil
+}
+
+// QueryContractCallReceipt returns the receipt, logs and revert reason of the call made by a contract_call proposal
+func (s *CmtRPCService) QueryContractCallReceipt(pid string) (*StakeQueryResult, error) {
+	var receipt governance.ContractCallReceipt
+	h, err := s.getParsedFromJson("/governance/contract_call_receipt", []byte(pid), &receipt, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, &receipt}, nil
+}
+
+// QueryPause returns the active emergency pause, or the no data error if there is none
+func (s *CmtRPCService) QueryPause() (*StakeQueryResult, error) {
+	var pause governance.Pause
+	h, err := s.getParsedFromJson("/governance/pause", []byte{0}, &pause, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, &pause}, nil
+}
+
+// QueryPauses returns all the emergency pauses, the latest first
+func (s *CmtRPCService) QueryPauses() (*StakeQueryResult, error) {
+	var pauses []*governance.Pause
+	h, err := s.getParsedFromJson("/governance/pauses", []byte{0}, &pauses, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, pauses}, nil
+}
+
+// QueryConsensusParams returns the tendermint consensus params in effect,
+// i.e. the ones of the genesis with the approved consensus_params proposals applied
+func (s *CmtRPCService) QueryConsensusParams() (*StakeQueryResult, error) {
+	var params ttypes.ConsensusParams
+	h, err := s.getParsedFromJson("/governance/consensus_params", []byte{0}, &params, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, params}, nil
+}
+
+// QueryLibEniDownload returns the progress and the last error of the download of the library
+// of a deploy_libeni proposal on the node
+func (s *CmtRPCService) QueryLibEniDownload(pid string) (*StakeQueryResult, error) {
+	var progress governance.DownloadProgress
+	h, err := s.getParsedFromJson("/governance/download", []byte(pid), &progress, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, &progress}, nil
+}
+
+// QueryLibEniDownloads returns the library downloads run by the node since it started, the latest first
+func (s *CmtRPCService) QueryLibEniDownloads() (*StakeQueryResult, error) {
+	var downloads []*governance.DownloadProgress
+	h, err := s.getParsedFromJson("/governance/downloads", []byte{0}, &downloads, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, downloads}, nil
+}
+
 func (s *CmtRPCService) QueryParams(height uint64) (*StakeQueryResult, error) {
 	var params utils.Params
 	h, err := s.getParsedFromJson("/key", utils.ParamKey, &params, height)
@@ -620,3 +1097,8 @@ func (s *CmtRPCService) QueryParams(height uint64) (*StakeQueryResult, error) {
 	}
 	return &StakeQueryResult{h, params}, nil
 }
+
+// GetParamSchema returns the type and the constraints of the params which can be changed by governance
+func (s *CmtRPCService) GetParamSchema() ([]utils.ParamSchema, error) {
+	return utils.GetParamSchema(), nil
+}
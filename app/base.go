@@ -5,10 +5,7 @@ import (
 	"database/sql"
 	goerr "errors"
 	"math/big"
-	"strings"
 
-	"github.com/vangjvn/devchain/modules/governance"
-	"github.com/vangjvn/devchain/modules/stake"
 	"github.com/vangjvn/devchain/sdk"
 	"github.com/vangjvn/devchain/sdk/dbm"
 	"github.com/vangjvn/devchain/sdk/errors"
@@ -16,14 +13,11 @@ import (
 	"github.com/vangjvn/devchain/server"
 	ttypes "github.com/vangjvn/devchain/types"
 	"github.com/vangjvn/devchain/utils"
-	"github.com/vangjvn/devchain/version"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth"
 	abci "github.com/tendermint/tendermint/abci/types"
-
-	"github.com/tendermint/tendermint/crypto/ed25519"
 	"golang.org/x/crypto/ripemd160"
 )
 
@@ -46,34 +40,14 @@ var (
 // NewBaseApp extends a StoreApp with a handler and a ticker,
 // which it binds to the proper abci calls
 func NewBaseApp(store *StoreApp, ethApp *EthermintApplication, ethereum *eth.Ethereum) (*BaseApp, error) {
-	// init pending proposals
-	pendingProposals := governance.GetPendingProposals()
-	if len(pendingProposals) > 0 {
-		proposalsTS := make(map[string]int64)
-		proposalsBH := make(map[string]int64)
-		for _, pp := range pendingProposals {
-			if pp.ExpireTimestamp > 0 {
-				proposalsTS[pp.Id] = pp.ExpireTimestamp
-			} else {
-				proposalsBH[pp.Id] = pp.ExpireBlockHeight
-			}
-
-			if pp.Type == governance.DEPLOY_LIBENI_PROPOSAL {
-				dp := governance.GetProposalById(pp.Id)
-				if dp.Detail["status"] != "ready" {
-					governance.DownloadLibEni(dp)
-				}
-			}
-		}
-		utils.PendingProposal.BatchAddTS(proposalsTS)
-		utils.PendingProposal.BatchAddBH(proposalsBH)
-	}
-
+	// the params are loaded before the modules, e.g. the resumed downloads are verified against the publisher keys
 	b := store.Append().Get(utils.ParamKey)
 	if b != nil {
 		utils.LoadParams(b)
 	}
 
+	loadModules()
+
 	app := &BaseApp{
 		StoreApp:  store,
 		EthApp:    ethApp,
@@ -101,20 +75,14 @@ func (app *BaseApp) Info(req abci.RequestInfo) abci.ResponseInfo {
 		return ethInfoRes
 	}
 
-	rp := governance.GetRetiringProposal(version.Version)
-	if rp != nil {
-		if rp.ExpireBlockHeight <= lbh {
-			rp = governance.GetProposalById(rp.Id)
-			if rp.Detail["status"] == "success" {
+	relaunched := false
+	for _, m := range modules {
+		if rm, ok := m.(RetireModule); ok {
+			retired, r := rm.ResumeRetirement(lbh)
+			if retired {
 				server.StopFlag <- true
 			}
-		} else if rp.ExpireBlockHeight == lbh+1 {
-			if rp.Result == "Approved" {
-				utils.RetiringProposalId = rp.Id
-			}
-		} else {
-			// check ahead one block
-			utils.PendingProposal.Add(rp.Id, 0, rp.ExpireBlockHeight-1)
+			relaunched = relaunched || r
 		}
 	}
 
@@ -123,10 +91,10 @@ func (app *BaseApp) Info(req abci.RequestInfo) abci.ResponseInfo {
 	// If the chain has just relaunched from a retired version,
 	// then use the old algorithm to match the old hash
 	var travisDbHash []byte
-	if governance.GetLatestRetiredHeight() == lbh {
+	if relaunched {
 		travisDbHash = app.StoreApp.GetOldDbHash()
 	} else {
-		travisDbHash = app.StoreApp.GetDbHash()
+		travisDbHash = app.StoreApp.GetDbHash(lbh)
 	}
 
 	travisInfoRes.LastBlockAppHash = finalAppHash(ethInfoRes.LastBlockAppHash, travisInfoRes.LastBlockAppHash, travisDbHash, travisInfoRes.LastBlockHeight, nil)
@@ -142,6 +110,9 @@ func (app *BaseApp) DeliverTx(txBytes []byte) abci.ResponseDeliverTx {
 	}
 
 	if utils.IsEthTx(tx) {
+		if err := checkEthTx(tx.To(), app.WorkingHeight()); err != nil {
+			return errors.DeliverResult(err)
+		}
 		if checkedTx, ok := app.checkedTx[tx.Hash()]; ok {
 			tx = checkedTx
 		} else {
@@ -174,6 +145,10 @@ func (app *BaseApp) CheckTx(txBytes []byte) abci.ResponseCheckTx {
 	}
 
 	if utils.IsEthTx(tx) {
+		// governance and stake txs are not EVM txs, they are never paused
+		if err := checkEthTx(tx.To(), app.WorkingHeight()); err != nil {
+			return errors.CheckResult(err)
+		}
 		resp := app.EthApp.CheckTx(tx)
 		app.logger.Debug("EthApp CheckTx response", "resp", resp)
 		if resp.IsErr() {
@@ -204,12 +179,20 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		panic(err)
 	}
 	app.deliverSqlTx = deliverSqlTx
-	stake.SetDeliverSqlTx(deliverSqlTx)
-	governance.SetDeliverSqlTx(deliverSqlTx)
+	for _, m := range modules {
+		if sm, ok := m.(SqlModule); ok {
+			sm.SetDeliverSqlTx(deliverSqlTx)
+		}
+	}
 	// init end
 
 	app.proposer = req.Header.Proposer
 
+	ctx := ttypes.NewContext(app.GetChainID(), app.WorkingHeight(), app.blockTime, app.EthApp.DeliverTxState())
+	for _, m := range modules {
+		m.BeginBlock(ctx, app.Append(), req)
+	}
+
 	return abci.ResponseBeginBlock{}
 }
 
@@ -219,52 +202,39 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 	utils.BlockGasFee = big.NewInt(0).Add(utils.BlockGasFee, app.TotalUsedGasFee)
 
 	// Deactivate validators that not in the list of preserved validators
-	if utils.RetiringProposalId != "" {
-		if proposal := governance.GetProposalById(utils.RetiringProposalId); proposal != nil {
-			pks := strings.Split(proposal.Detail["preserved_validators"].(string), ",")
-			vs := stake.GetCandidates().Validators()
-			inaVs := make(stake.Validators, 0)
-			abciVs := make([]abci.Validator, 0)
-			pvSize := 0
-			for _, v := range vs {
-				i := 0
-				for ; i < len(pks); i++ {
-					if pks[i] == ttypes.PubKeyString(v.PubKey) {
-						v.VotingPower = 1000
-						abciVs = append(abciVs, v.ABCIValidator())
-						pvSize++
-						break
-					}
-				}
-				if i == len(pks) {
-					inaVs = append(inaVs, v)
-					pk := v.PubKey.PubKey.(ed25519.PubKeyEd25519)
-					abciVs = append(abciVs, abci.Ed25519Validator(pk[:], 0))
-				}
+	for _, m := range modules {
+		if rm, ok := m.(RetireModule); ok {
+			diff, retired, err := rm.Retire()
+			if err != nil {
+				app.logger.Error(err.Error())
+				continue
 			}
-			if pvSize >= 1 {
-				inaVs.Deactivate()
-				app.AddValChange(abciVs)
+			if retired {
+				app.AddValChange(diff)
 				toBeShutdown = true
-				governance.UpdateRetireProgramStatus(utils.RetiringProposalId, "success")
-			} else {
-				governance.UpdateRetireProgramStatus(utils.RetiringProposalId, "rejected")
 			}
-		} else {
-			app.logger.Error("Getting invalid RetiringProposalId")
 		}
 	}
 
+	var consensusParamUpdates *abci.ConsensusParams
 	if !toBeShutdown { // should not update validator set twice if the node is to be shutdown
-		// calculate the validator set difference
-		diff, err := stake.UpdateValidatorSet(app.Append())
-		if err != nil {
-			panic(err)
+		ctx := ttypes.NewContext(app.GetChainID(), app.WorkingHeight(), app.blockTime, app.EthApp.DeliverTxState())
+		for _, m := range modules {
+			// calculate the validator set difference
+			diff, cp, err := m.EndBlock(ctx, app.Append(), req)
+			if err != nil {
+				panic(err)
+			}
+			app.AddValChange(diff)
+			if cp != nil {
+				consensusParamUpdates = cp
+			}
 		}
-		app.AddValChange(diff)
 	}
 
-	return app.StoreApp.EndBlock(req)
+	res = app.StoreApp.EndBlock(req)
+	res.ConsensusParamUpdates = consensusParamUpdates
+	return res
 }
 
 func (app *BaseApp) Commit() (res abci.ResponseCommit) {
@@ -281,8 +251,7 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 			if err != nil {
 				panic(err)
 			}
-			stake.ResetDeliverSqlTx()
-			governance.ResetDeliverSqlTx()
+			app.resetDeliverSqlTx()
 		}
 	} else {
 		if app.deliverSqlTx != nil {
@@ -291,10 +260,10 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 			if err != nil {
 				panic(err)
 			}
-			stake.ResetDeliverSqlTx()
-			governance.ResetDeliverSqlTx()
+			app.resetDeliverSqlTx()
 		}
 	}
+	afterCommit()
 
 	workingHeight := app.WorkingHeight()
 
@@ -307,12 +276,30 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	app.TotalUsedGasFee = big.NewInt(0)
 
 	res = app.StoreApp.Commit()
-	dbHash := app.StoreApp.GetDbHash()
+	dbHash := app.StoreApp.GetDbHash(workingHeight)
 	res.Data = finalAppHash(ethAppCommit.Data, res.Data, dbHash, workingHeight, nil)
 
 	return
 }
 
+func (app *BaseApp) resetDeliverSqlTx() {
+	for _, m := range modules {
+		if sm, ok := m.(SqlModule); ok {
+			sm.ResetDeliverSqlTx()
+		}
+	}
+}
+
+// InitGenesis hands the genesis doc over to every registered module
+func (app *BaseApp) InitGenesis(genDoc *ttypes.GenesisDoc) error {
+	for _, m := range modules {
+		if err := m.InitGenesis(app.Append(), genDoc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func finalAppHash(ethCommitHash []byte, travisCommitHash []byte, dbHash []byte, workingHeight int64, store *state.SimpleDB) []byte {
 
 	hasher := ripemd160.New()
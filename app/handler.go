@@ -7,8 +7,6 @@ import (
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 
-	"github.com/vangjvn/devchain/modules/governance"
-	"github.com/vangjvn/devchain/modules/stake"
 	"github.com/vangjvn/devchain/sdk"
 	"github.com/vangjvn/devchain/sdk/errors"
 	"github.com/vangjvn/devchain/sdk/state"
@@ -28,18 +26,12 @@ func (app BaseApp) checkHandler(ctx types.Context, store state.SimpleDB, tx *eth
 		return errors.CheckResult(err)
 	}
 
-	name, err := lookupRoute(travisTx)
+	m, err := lookupModule(travisTx)
 	if err != nil {
 		return errors.CheckResult(err)
 	}
 
-	var res sdk.CheckResult
-	if name == "stake" {
-		res, err = stake.CheckTx(ctx, store, travisTx)
-	} else if name == "governance" {
-		res, err = governance.CheckTx(ctx, store, travisTx)
-	}
-
+	res, err := m.CheckTx(ctx, store, travisTx)
 	if err != nil {
 		return errors.CheckResult(err)
 	}
@@ -70,21 +62,12 @@ func (app BaseApp) deliverHandler(ctx types.Context, store state.SimpleDB, tx *e
 	ctx.WithSigners(from)
 	ctx.SetNonce(tx.Nonce())
 
-	name, err := lookupRoute(travisTx)
+	m, err := lookupModule(travisTx)
 	if err != nil {
 		return errors.DeliverResult(err)
 	}
 
-	var res sdk.DeliverResult
-	switch name {
-	case "stake":
-		res, err = stake.DeliverTx(ctx, store, travisTx, hash)
-	case "governance":
-		res, err = governance.DeliverTx(ctx, store, travisTx, hash)
-	default:
-		return errors.DeliverResult(errors.ErrUnknownTxType(travisTx.Unwrap()))
-	}
-
+	res, err := m.DeliverTx(ctx, store, travisTx, hash)
 	if err != nil {
 		return errors.DeliverResult(err)
 	}
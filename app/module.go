@@ -0,0 +1,130 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/sdk/errors"
+	"github.com/vangjvn/devchain/sdk/state"
+	ttypes "github.com/vangjvn/devchain/types"
+)
+
+// Module is a native module plugged into the BaseApp.
+// Txs whose kind is prefixed with "<Name()>/" are routed to the module,
+// and the paths returned by QueryRoutes are served by StoreApp.Query.
+type Module interface {
+	Name() string
+
+	// InitGenesis is called once when the chain is started from the genesis file
+	InitGenesis(store state.SimpleDB, genDoc *ttypes.GenesisDoc) error
+	// Load restores the state the module caches in memory from the committed state when the node starts
+	Load()
+	// AfterCommit is called after each block is committed or rolled back, to reload the state cached in memory
+	AfterCommit()
+
+	// CheckEthTx returns an error if the module rejects an EVM transaction to the address at the block height,
+	// to is nil for a contract creation. It is called by both CheckTx and DeliverTx.
+	CheckEthTx(to *common.Address, blockHeight int64) error
+
+	CheckTx(ctx ttypes.Context, store state.SimpleDB, tx sdk.Tx) (sdk.CheckResult, error)
+	DeliverTx(ctx ttypes.Context, store state.SimpleDB, tx sdk.Tx, hash []byte) (sdk.DeliverResult, error)
+
+	// QueryRoutes maps the full abci query path, e.g. "/validators", to its handler
+	QueryRoutes() map[string]sdk.QueryHandler
+
+	BeginBlock(ctx ttypes.Context, store state.SimpleDB, req abci.RequestBeginBlock)
+	// EndBlock returns the validator changes made by the module in this block,
+	// and the consensus params to update, nil if they are unchanged
+	EndBlock(ctx ttypes.Context, store state.SimpleDB, req abci.RequestEndBlock) ([]abci.Validator, *abci.ConsensusParams, error)
+}
+
+// SqlModule is implemented by modules keeping their state in the sqlite database.
+// They join the sql transaction which is opened in BeginBlock and committed,
+// or rolled back, together with the block.
+type SqlModule interface {
+	SetDeliverSqlTx(tx *sql.Tx)
+	ResetDeliverSqlTx()
+}
+
+// RetireModule is implemented by the module retiring the program. The node is stopped once the block
+// retiring the program is committed, and the other modules don't change the validators at that block.
+type RetireModule interface {
+	// ResumeRetirement is called with the last block height when the node starts, retired tells whether the program
+	// has been retired at that height, relaunched whether the chain has just relaunched from a retired version
+	ResumeRetirement(lastBlockHeight int64) (retired, relaunched bool)
+	// Retire returns the validator changes, and whether the program is retired at the end of this block
+	Retire() ([]abci.Validator, bool, error)
+}
+
+var (
+	modules       []Module
+	moduleByName  = make(map[string]Module)
+	moduleQueries = make(map[string]sdk.QueryHandler)
+)
+
+// RegisterModule adds a module to the application, it must be called before the node is started.
+// Modules are invoked in the order they are registered.
+func RegisterModule(m Module) {
+	name := m.Name()
+	if _, ok := moduleByName[name]; ok {
+		panic(fmt.Sprintf("module %s has already been registered", name))
+	}
+
+	routes := m.QueryRoutes()
+	for path := range routes {
+		if _, ok := moduleQueries[path]; ok || path == "/store" || path == "/key" {
+			panic(fmt.Sprintf("query path %s of module %s has already been registered", path, name))
+		}
+	}
+	for path, h := range routes {
+		moduleQueries[path] = h
+	}
+
+	modules = append(modules, m)
+	moduleByName[name] = m
+}
+
+// Modules returns all registered modules
+func Modules() []Module {
+	return modules
+}
+
+// loadModules restores the in-memory state of every registered module from the committed state
+func loadModules() {
+	for _, m := range modules {
+		m.Load()
+	}
+}
+
+// afterCommit has every registered module reload its in-memory state once the block is committed or rolled back
+func afterCommit() {
+	for _, m := range modules {
+		m.AfterCommit()
+	}
+}
+
+// checkEthTx returns the error of the first module rejecting an EVM transaction
+func checkEthTx(to *common.Address, blockHeight int64) error {
+	for _, m := range modules {
+		if err := m.CheckEthTx(to, blockHeight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func lookupModule(tx sdk.Tx) (Module, error) {
+	name, err := lookupRoute(tx)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := moduleByName[name]
+	if !ok {
+		return nil, errors.ErrUnknownModule(name)
+	}
+	return m, nil
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/vangjvn/devchain/modules/governance"
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/sdk/state"
+	ttypes "github.com/vangjvn/devchain/types"
+)
+
+type fakeModule struct {
+	name     string
+	paths    []string
+	ethTxErr error
+}
+
+func (m *fakeModule) Name() string { return m.name }
+
+func (m *fakeModule) InitGenesis(store state.SimpleDB, genDoc *ttypes.GenesisDoc) error { return nil }
+
+func (m *fakeModule) Load() {}
+
+func (m *fakeModule) AfterCommit() {}
+
+func (m *fakeModule) CheckEthTx(to *common.Address, blockHeight int64) error { return m.ethTxErr }
+
+func (m *fakeModule) CheckTx(ctx ttypes.Context, store state.SimpleDB, tx sdk.Tx) (sdk.CheckResult, error) {
+	return sdk.CheckResult{}, nil
+}
+
+func (m *fakeModule) DeliverTx(ctx ttypes.Context, store state.SimpleDB, tx sdk.Tx, hash []byte) (sdk.DeliverResult, error) {
+	return sdk.DeliverResult{}, nil
+}
+
+func (m *fakeModule) QueryRoutes() map[string]sdk.QueryHandler {
+	routes := make(map[string]sdk.QueryHandler)
+	for _, path := range m.paths {
+		routes[path] = func(data []byte) ([]byte, error) { return data, nil }
+	}
+	return routes
+}
+
+func (m *fakeModule) BeginBlock(ctx ttypes.Context, store state.SimpleDB, req abci.RequestBeginBlock) {
+}
+
+func (m *fakeModule) EndBlock(ctx ttypes.Context, store state.SimpleDB, req abci.RequestEndBlock) ([]abci.Validator, *abci.ConsensusParams, error) {
+	return nil, nil, nil
+}
+
+// withModules runs f against an empty registry, the registered modules are restored afterwards
+func withModules(f func()) {
+	saved, savedByName, savedQueries := modules, moduleByName, moduleQueries
+	defer func() { modules, moduleByName, moduleQueries = saved, savedByName, savedQueries }()
+
+	modules, moduleByName, moduleQueries = nil, make(map[string]Module), make(map[string]sdk.QueryHandler)
+	f()
+}
+
+func TestRegisterModule(t *testing.T) {
+	withModules(func() {
+		stake := &fakeModule{name: "stake", paths: []string{"/validators"}}
+		gov := &fakeModule{name: "governance", paths: []string{"/governance/proposals"}}
+		RegisterModule(stake)
+		RegisterModule(gov)
+
+		assert.Equal(t, []Module{stake, gov}, Modules(), "modules are kept in registration order")
+		assert.Contains(t, moduleQueries, "/validators")
+		assert.Contains(t, moduleQueries, "/governance/proposals")
+
+		assert.Panics(t, func() { RegisterModule(&fakeModule{name: "stake"}) }, "duplicate name")
+		assert.Panics(t, func() { RegisterModule(&fakeModule{name: "other", paths: []string{"/validators"}}) }, "duplicate query path")
+		assert.Panics(t, func() { RegisterModule(&fakeModule{name: "store", paths: []string{"/store"}}) }, "reserved query path")
+		assert.Len(t, Modules(), 2, "a rejected module is not registered")
+		assert.NotContains(t, moduleByName, "other")
+	})
+}
+
+func TestModuleRouting(t *testing.T) {
+	withModules(func() {
+		gov := &fakeModule{name: "governance"}
+		RegisterModule(gov)
+
+		m, err := lookupModule(governance.TxVote{ProposalId: "1", Answer: "Y"}.Wrap())
+		assert.NoError(t, err)
+		assert.Equal(t, Module(gov), m)
+
+		moduleByName = map[string]Module{}
+		_, err = lookupModule(governance.TxVote{ProposalId: "1", Answer: "Y"}.Wrap())
+		assert.Error(t, err, "unknown module")
+	})
+}
+
+func TestCheckEthTx(t *testing.T) {
+	withModules(func() {
+		paused := errors.New("paused")
+		RegisterModule(&fakeModule{name: "first"})
+		RegisterModule(&fakeModule{name: "second", ethTxErr: paused})
+		RegisterModule(&fakeModule{name: "third", ethTxErr: errors.New("not reached")})
+
+		assert.Equal(t, paused, checkEthTx(nil, 1))
+	})
+}
@@ -3,9 +3,7 @@ package app
 import (
 	"bytes"
 	"database/sql"
-	"encoding/json"
 	"fmt"
-	"github.com/vangjvn/devchain/modules/stake"
 	"math/big"
 	"path"
 	"path/filepath"
@@ -14,17 +12,16 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 	"golang.org/x/crypto/ripemd160"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/tendermint/iavl"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	tDB "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 
-	"github.com/vangjvn/devchain/modules/governance"
 	"github.com/vangjvn/devchain/sdk/dbm"
 	"github.com/vangjvn/devchain/sdk/errors"
 	sm "github.com/vangjvn/devchain/sdk/state"
+	"github.com/vangjvn/devchain/utils"
 	"github.com/tendermint/go-amino"
 )
 
@@ -186,26 +183,20 @@ func (app *StoreApp) Query(reqQuery abci.RequestQuery) (resQuery abci.ResponseQu
 			_, value := tree.GetVersioned(key, height)
 			resQuery.Value = value
 		}
-	case "/validators":
-		candidates := stake.QueryCandidates()
-		b, _ := json.Marshal(candidates)
-		resQuery.Value = b
-	case "/validator":
-		address := common.HexToAddress(string(reqQuery.Data))
-		candidate := stake.QueryCandidateByAddress(address)
-		if candidate != nil {
-			b, _ := json.Marshal(candidate)
-			resQuery.Value = b
-		} else {
-			resQuery.Value = []byte{}
-		}
-	case "/governance/proposals":
-		proposals := governance.QueryProposals()
-		b, _ := json.Marshal(proposals)
-		resQuery.Value = b
 	default:
-		resQuery.Code = errors.CodeTypeUnknownRequest
-		resQuery.Log = cmn.Fmt("Unexpected Query path: %v", reqQuery.Path)
+		handler, ok := moduleQueries[reqQuery.Path]
+		if !ok {
+			resQuery.Code = errors.CodeTypeUnknownRequest
+			resQuery.Log = cmn.Fmt("Unexpected Query path: %v", reqQuery.Path)
+			break
+		}
+		value, err := handler(reqQuery.Data)
+		if err != nil {
+			resQuery.Code = errors.Wrap(err).ErrorCode()
+			resQuery.Log = err.Error()
+			break
+		}
+		resQuery.Value = value
 	}
 
 	return
@@ -305,9 +296,17 @@ func (app *StoreApp) GetOldDbHash() []byte {
 	return hashing(hashes)
 }
 
-func (app *StoreApp) GetDbHash() []byte {
+// upgradeDbHashTables are hashed in addition to the former tables from the governance upgrade height,
+// so that the history before the upgrade keeps its app hash
+var upgradeDbHashTables = []string{"governance_vote_delegation", "governance_validator_snapshot", "candidate_verification_sign_offs",
+	"governance_grant", "governance_pause", "governance_consensus_params"}
+
+func (app *StoreApp) GetDbHash(height int64) []byte {
 	db, _ := dbm.Sqliter.GetDB()
 	tables := []string{"candidates", "governance_proposal", "governance_vote", "candidate_account_update_requests"}
+	if utils.IsGovernanceUpgraded(height) {
+		tables = append(tables, upgradeDbHashTables...)
+	}
 	hashes := make([]byte, len(tables))
 	for _, table := range tables {
 		hashes = append(hashes, getTableHash(db, table)...)
@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	govcmd "github.com/vangjvn/devchain/modules/governance/commands"
 	stakecmd "github.com/vangjvn/devchain/modules/stake/commands"
 	"github.com/vangjvn/devchain/sdk/client/commands"
 	"github.com/vangjvn/devchain/sdk/client/commands/query"
@@ -24,6 +25,21 @@ func prepareClientCommands() {
 	query.RootCmd.AddCommand(
 		stakecmd.CmdQueryValidator,
 		stakecmd.CmdQueryValidators,
+		stakecmd.CmdQueryValidatorVerification,
+		govcmd.CmdQueryProposals,
+		govcmd.CmdQueryProposal,
+		govcmd.CmdQueryVotes,
+		govcmd.CmdQueryProposalSnapshot,
+		govcmd.CmdQueryEvents,
+		govcmd.CmdQueryVoteDelegations,
+		govcmd.CmdQueryGrant,
+		govcmd.CmdQueryGrants,
+		govcmd.CmdQueryContractCallReceipt,
+		govcmd.CmdQueryPause,
+		govcmd.CmdQueryPauses,
+		govcmd.CmdQueryConsensusParams,
+		govcmd.CmdQueryDownload,
+		govcmd.CmdQueryDownloads,
 	)
 
 	// set up the middleware
@@ -39,6 +55,24 @@ func prepareClientCommands() {
 		stakecmd.CmdDeactivateCandidacy,
 		stakecmd.CmdUpdateCandidacyAccount,
 		stakecmd.CmdAcceptCandidacyAccountUpdate,
+		govcmd.CmdProposeTransferFund,
+		govcmd.CmdProposeChangeParam,
+		govcmd.CmdProposeDeployLibEni,
+		govcmd.CmdProposeRetireProgram,
+		govcmd.CmdProposeUpgradeProgram,
+		govcmd.CmdProposeText,
+		govcmd.CmdProposeAddValidator,
+		govcmd.CmdProposeRemoveValidator,
+		govcmd.CmdProposeGrant,
+		govcmd.CmdProposeCancelGrant,
+		govcmd.CmdProposeContractCall,
+		govcmd.CmdProposeEmergencyPause,
+		govcmd.CmdProposeUnpause,
+		govcmd.CmdProposeConsensusParams,
+		govcmd.CmdVote,
+		govcmd.CmdCancelProposal,
+		govcmd.CmdDelegateVote,
+		govcmd.CmdRevokeVoteDelegation,
 	)
 
 	clientCmd.AddCommand(
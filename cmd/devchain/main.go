@@ -7,6 +7,9 @@ import (
 
 	"github.com/tendermint/tendermint/libs/cli"
 
+	"github.com/vangjvn/devchain/app"
+	"github.com/vangjvn/devchain/modules/governance"
+	"github.com/vangjvn/devchain/modules/stake"
 	"github.com/vangjvn/devchain/sdk/client/commands/auto"
 	basecmd "github.com/vangjvn/devchain/server/commands"
 )
@@ -25,6 +28,10 @@ var (
 )
 
 func main() {
+	// the built-in modules, invoked in this order
+	app.RegisterModule(stake.NewModule())
+	app.RegisterModule(governance.NewModule())
+
 	// disable sorting
 	cobra.EnableCommandSorting = false
 
@@ -17,5 +17,6 @@ func prepareNodeCommands() {
 		basecmd.InitCmd,
 		basecmd.GetStartCmd(),
 		basecmd.ShowNodeIDCmd,
+		basecmd.ArtifactsCmd,
 	)
 }
@@ -0,0 +1,385 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/vangjvn/devchain/modules/governance"
+	stakecmd "github.com/vangjvn/devchain/modules/stake/commands"
+	"github.com/vangjvn/devchain/utils"
+)
+
+/**
+The governance/query/proposals is to query all proposals. Not signed.
+If any of the filter flags is given, only a page of the matching proposals is returned.
+
+* Type
+* Status, Pending or the result of the proposal
+* Proposer
+* Block height range
+* Page and number of proposals per page
+
+The governance/query/proposal is to query a single proposal. Not signed.
+
+* Proposal ID
+
+The governance/query/votes is to query the votes of a proposal, or the votes cast by a validator. Not signed.
+
+* Proposal ID or voter
+
+The governance/query/proposal-snapshot is to query the validators a proposal is voted on by, with their voting power
+at the creation of the proposal. Not signed.
+
+* Proposal ID
+
+The governance/query/vote-delegations is to query the delegations of the governance voting power. Not signed.
+
+The governance/query/events is to query the log of the state transitions of a proposal. Not signed.
+
+* Proposal ID
+
+The governance/query/grant is to query the payout state of a grant. Not signed.
+
+* Grant ID, i.e. the ID of the grant proposal
+
+The governance/query/grants is to query the grants. Not signed.
+
+* Status, Active, Completed or Cancelled
+
+The governance/query/contract-call-receipt is to query the receipt, logs and revert reason of the call
+made by an approved contract_call proposal. Not signed.
+
+* Proposal ID
+
+The governance/query/pause is to query the active emergency pause. Not signed.
+
+The governance/query/pauses is to query all the emergency pauses. Not signed.
+
+The governance/query/consensus-params is to query the tendermint consensus params in effect. Not signed.
+*/
+
+// nolint
+const (
+	FlagType       = "type"
+	FlagStatus     = "status"
+	FlagProposer   = "proposer"
+	FlagFromHeight = "from-height"
+	FlagToHeight   = "to-height"
+	FlagPage       = "page"
+	FlagPerPage    = "per-page"
+	FlagVoter      = "voter"
+)
+
+// nolint
+var (
+	CmdQueryProposals = &cobra.Command{
+		Use:   "proposals",
+		RunE:  cmdQueryProposals,
+		Short: "Query a list of all governance proposals",
+	}
+
+	CmdQueryProposal = &cobra.Command{
+		Use:   "proposal",
+		RunE:  cmdQueryProposal,
+		Short: "Query a governance proposal by its ID",
+	}
+
+	CmdQueryVotes = &cobra.Command{
+		Use:   "votes",
+		RunE:  cmdQueryVotes,
+		Short: "Query the votes of a governance proposal",
+	}
+
+	CmdQueryProposalSnapshot = &cobra.Command{
+		Use:   "proposal-snapshot",
+		RunE:  cmdQueryProposalSnapshot,
+		Short: "Query the validators and voting power snapshotted at the creation of a governance proposal",
+	}
+
+	CmdQueryVoteDelegations = &cobra.Command{
+		Use:   "vote-delegations",
+		RunE:  cmdQueryVoteDelegations,
+		Short: "Query the delegations of the governance voting power between validators",
+	}
+
+	CmdQueryEvents = &cobra.Command{
+		Use:   "proposal-events",
+		RunE:  cmdQueryEvents,
+		Short: "Query the event log of a governance proposal",
+	}
+
+	CmdQueryGrant = &cobra.Command{
+		Use:   "grant",
+		RunE:  cmdQueryGrant,
+		Short: "Query the payout state of a grant",
+	}
+
+	CmdQueryGrants = &cobra.Command{
+		Use:   "grants",
+		RunE:  cmdQueryGrants,
+		Short: "Query the grants, optionally by status",
+	}
+
+	CmdQueryContractCallReceipt = &cobra.Command{
+		Use:   "contract-call-receipt",
+		RunE:  cmdQueryContractCallReceipt,
+		Short: "Query the receipt of the call made by a contract_call proposal",
+	}
+
+	CmdQueryPause = &cobra.Command{
+		Use:   "pause",
+		RunE:  cmdQueryPause,
+		Short: "Query the active emergency pause",
+	}
+
+	CmdQueryPauses = &cobra.Command{
+		Use:   "pauses",
+		RunE:  cmdQueryPauses,
+		Short: "Query all the emergency pauses",
+	}
+
+	CmdQueryConsensusParams = &cobra.Command{
+		Use:   "consensus-params",
+		RunE:  cmdQueryConsensusParams,
+		Short: "Query the tendermint consensus params in effect",
+	}
+
+	CmdQueryDownload = &cobra.Command{
+		Use:   "libeni-download",
+		RunE:  cmdQueryDownload,
+		Short: "Query the download progress of the library of a deploy_libeni proposal on the node",
+	}
+
+	CmdQueryDownloads = &cobra.Command{
+		Use:   "libeni-downloads",
+		RunE:  cmdQueryDownloads,
+		Short: "Query the library downloads run by the node since it started",
+	}
+)
+
+func init() {
+	//Add Flags
+	fsPid := flag.NewFlagSet("", flag.ContinueOnError)
+	fsPid.String(FlagProposalId, "", "proposal ID")
+
+	fsFilter := flag.NewFlagSet("", flag.ContinueOnError)
+	fsFilter.String(FlagType, "", "type of the proposals, e.g. transfer_fund")
+	fsFilter.String(FlagStatus, "", "Pending, Queued, Approved, Rejected, Expired or Cancelled")
+	fsFilter.String(FlagProposer, "", "address of the proposer")
+	fsFilter.Int64(FlagFromHeight, 0, "lowest block height the proposals were created at")
+	fsFilter.Int64(FlagToHeight, 0, "highest block height the proposals were created at")
+	fsFilter.Int(FlagPage, 1, "page number, starting from 1")
+	fsFilter.Int(FlagPerPage, governance.DEFAULT_PROPOSALS_PER_PAGE, "number of proposals per page")
+
+	fsVoter := flag.NewFlagSet("", flag.ContinueOnError)
+	fsVoter.String(FlagVoter, "", "address of the voter")
+
+	CmdQueryProposals.Flags().AddFlagSet(fsFilter)
+	CmdQueryProposal.Flags().AddFlagSet(fsPid)
+	CmdQueryVotes.Flags().AddFlagSet(fsPid)
+	CmdQueryVotes.Flags().AddFlagSet(fsVoter)
+	CmdQueryProposalSnapshot.Flags().AddFlagSet(fsPid)
+	CmdQueryEvents.Flags().AddFlagSet(fsPid)
+	CmdQueryGrant.Flags().String(FlagGrantId, "", "ID of the grant proposal")
+	CmdQueryGrants.Flags().String(FlagStatus, "", "Active, Completed or Cancelled")
+	CmdQueryContractCallReceipt.Flags().AddFlagSet(fsPid)
+	CmdQueryDownload.Flags().AddFlagSet(fsPid)
+}
+
+func cmdQueryProposals(cmd *cobra.Command, args []string) error {
+	filtered := false
+	for _, f := range []string{FlagType, FlagStatus, FlagProposer, FlagFromHeight, FlagToHeight, FlagPage, FlagPerPage} {
+		filtered = filtered || cmd.Flags().Changed(f)
+	}
+	if !filtered {
+		b, err := stakecmd.Get("/governance/proposals", []byte{0})
+		if err != nil {
+			return err
+		}
+		return stakecmd.Foutput(b)
+	}
+
+	proposer := viper.GetString(FlagProposer)
+	if proposer != "" && !common.IsHexAddress(proposer) {
+		return fmt.Errorf("please enter a valid proposer address using --proposer")
+	}
+	filter := governance.ProposalFilter{
+		Type:       viper.GetString(FlagType),
+		Status:     viper.GetString(FlagStatus),
+		Proposer:   proposer,
+		FromHeight: viper.GetInt64(FlagFromHeight),
+		ToHeight:   viper.GetInt64(FlagToHeight),
+		Page:       viper.GetInt(FlagPage),
+		PerPage:    viper.GetInt(FlagPerPage),
+	}
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return err
+	}
+
+	b, err := stakecmd.Get("/governance/proposals/filter", data)
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryProposal(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if utils.IsBlank(pid) {
+		return fmt.Errorf("please enter proposal ID using --proposal-id")
+	}
+
+	b, err := stakecmd.Get("/governance/proposal", []byte(pid))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryVotes(cmd *cobra.Command, args []string) error {
+	if voter := viper.GetString(FlagVoter); voter != "" {
+		if !common.IsHexAddress(voter) {
+			return fmt.Errorf("please enter a valid voter address using --voter")
+		}
+		b, err := stakecmd.Get("/governance/votes/voter", []byte(voter))
+		if err != nil {
+			return err
+		}
+		return stakecmd.Foutput(b)
+	}
+
+	pid := viper.GetString(FlagProposalId)
+	if utils.IsBlank(pid) {
+		return fmt.Errorf("please enter proposal ID using --proposal-id, or the voter using --voter")
+	}
+
+	b, err := stakecmd.Get("/governance/votes", []byte(pid))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryProposalSnapshot(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if utils.IsBlank(pid) {
+		return fmt.Errorf("please enter proposal ID using --proposal-id")
+	}
+
+	b, err := stakecmd.Get("/governance/snapshot", []byte(pid))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryVoteDelegations(cmd *cobra.Command, args []string) error {
+	b, err := stakecmd.Get("/governance/delegations", []byte{0})
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryEvents(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if utils.IsBlank(pid) {
+		return fmt.Errorf("please enter proposal ID using --proposal-id")
+	}
+
+	b, err := stakecmd.Get("/governance/events", []byte(pid))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryGrant(cmd *cobra.Command, args []string) error {
+	grantId := viper.GetString(FlagGrantId)
+	if utils.IsBlank(grantId) {
+		return fmt.Errorf("please enter the ID of the grant proposal using --grant-id")
+	}
+
+	b, err := stakecmd.Get("/governance/grant", []byte(grantId))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryGrants(cmd *cobra.Command, args []string) error {
+	data := []byte{0}
+	if status := viper.GetString(FlagStatus); status != "" {
+		data = []byte(status)
+	}
+
+	b, err := stakecmd.Get("/governance/grants", data)
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryContractCallReceipt(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if utils.IsBlank(pid) {
+		return fmt.Errorf("please enter proposal ID using --proposal-id")
+	}
+
+	b, err := stakecmd.Get("/governance/contract_call_receipt", []byte(pid))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryPause(cmd *cobra.Command, args []string) error {
+	b, err := stakecmd.Get("/governance/pause", []byte{0})
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryPauses(cmd *cobra.Command, args []string) error {
+	b, err := stakecmd.Get("/governance/pauses", []byte{0})
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryConsensusParams(cmd *cobra.Command, args []string) error {
+	b, err := stakecmd.Get("/governance/consensus_params", []byte{0})
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryDownload(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if utils.IsBlank(pid) {
+		return fmt.Errorf("please enter proposal ID using --proposal-id")
+	}
+
+	b, err := stakecmd.Get("/governance/download", []byte(pid))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryDownloads(cmd *cobra.Command, args []string) error {
+	b, err := stakecmd.Get("/governance/downloads", []byte{0})
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
@@ -0,0 +1,677 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/vangjvn/devchain/modules/governance"
+	txcmd "github.com/vangjvn/devchain/sdk/client/commands/txs"
+	"github.com/vangjvn/devchain/utils"
+)
+
+/*
+The governance/propose/* txs allow a validator to submit a proposal. Signed by the validator.
+
+The governance/propose/add_validator and governance/propose/remove_validator txs admit a declared candidate to,
+or evict a candidate from, the validator set once approved.
+
+* Candidate address
+* Reason
+
+The governance/propose/grant tx escrows an amount which is paid out to the recipient in tranches once approved,
+the governance/propose/cancel_grant tx claws back the amount of an active grant which hasn't been paid out yet.
+
+* Source and recipient accounts, amount, number of tranches and interval in blocks between them
+* Grant ID, i.e. the ID of the grant proposal
+
+The governance/propose/contract_call tx calls a contract from the governance account once approved,
+the call is made at the commit of the block and its receipt is stored with the proposal result.
+
+* Contract address, value in wei, hex encoded calldata and gas limit
+
+The governance/propose/emergency_pause tx pauses the EVM transactions once approved, governance and stake txs are not paused,
+the governance/propose/unpause tx lifts the active pause before it expires.
+
+* Contracts, the pause is limited to the calls to them if any is given
+* Duration in blocks, defaults to the emergency_pause_duration param
+
+The governance/propose/consensus_params tx changes the tendermint consensus params once approved,
+the update takes effect from the block following the execution.
+
+* Block size, tx size and block gossip params, the ones not given are unchanged
+
+The governance/vote tx allows a validator to vote on a pending proposal. Signed by the validator.
+
+* Proposal ID
+* Answer (Y/N/A/V)
+
+The governance/cancel tx allows the proposer to cancel its proposal before it is decided. Signed by the proposer.
+
+* Proposal ID
+
+The governance/delegate tx allows a validator to let another validator vote on its behalf. Signed by the validator.
+
+* Delegatee
+
+The governance/revoke_delegation tx revokes the delegation. Signed by the validator.
+*/
+
+// nolint
+const (
+	FlagTransferFrom        = "transfer-from"
+	FlagTransferTo          = "transfer-to"
+	FlagAmount              = "amount"
+	FlagReason              = "reason"
+	FlagName                = "name"
+	FlagValue               = "value"
+	FlagParams              = "params"
+	FlagVersion             = "version"
+	FlagFileUrl             = "file-url"
+	FlagMd5                 = "md5"
+	FlagSha256              = "sha256"
+	FlagSignature           = "signature"
+	FlagPreservedValidators = "preserved-validators"
+	FlagExpireTimestamp     = "expire-timestamp"
+	FlagExpireBlockHeight   = "expire-block-height"
+	FlagProposalId          = "proposal-id"
+	FlagTitle               = "title"
+	FlagDescription         = "description"
+	FlagDocumentUrl         = "document-url"
+	FlagDocumentHash        = "document-hash"
+	FlagAnswer              = "answer"
+	FlagExecutionDelay      = "execution-delay"
+	FlagDelegatee           = "delegatee"
+	FlagActivationHeight    = "activation-block-height"
+	FlagCandidate           = "candidate"
+	FlagGrantFrom           = "grant-from"
+	FlagGrantTo             = "grant-to"
+	FlagTranches            = "tranches"
+	FlagInterval            = "interval"
+	FlagGrantId             = "grant-id"
+	FlagCallTo              = "to"
+	FlagCallData            = "data"
+	FlagGas                 = "gas"
+	FlagContracts           = "contracts"
+	FlagDuration            = "duration"
+	FlagBlockMaxBytes       = "block-max-bytes"
+	FlagBlockMaxTxs         = "block-max-txs"
+	FlagBlockMaxGas         = "block-max-gas"
+	FlagTxMaxBytes          = "tx-max-bytes"
+	FlagTxMaxGas            = "tx-max-gas"
+	FlagBlockPartSizeBytes  = "block-part-size-bytes"
+)
+
+// nolint
+var (
+	CmdProposeTransferFund = &cobra.Command{
+		Use:   "propose-transfer-fund",
+		Short: "Propose to transfer fund from one account to another",
+		RunE:  cmdProposeTransferFund,
+	}
+	CmdProposeChangeParam = &cobra.Command{
+		Use:   "propose-change-param",
+		Short: "Propose to change a chain parameter",
+		RunE:  cmdProposeChangeParam,
+	}
+	CmdProposeDeployLibEni = &cobra.Command{
+		Use:   "propose-deploy-libeni",
+		Short: "Propose to deploy a new version of a LibENI library",
+		RunE:  cmdProposeDeployLibEni,
+	}
+	CmdProposeRetireProgram = &cobra.Command{
+		Use:   "propose-retire-program",
+		Short: "Propose to retire the program at a given block height",
+		RunE:  cmdProposeRetireProgram,
+	}
+	CmdProposeUpgradeProgram = &cobra.Command{
+		Use:   "propose-upgrade-program",
+		Short: "Propose to upgrade the program at a given block height",
+		RunE:  cmdProposeUpgradeProgram,
+	}
+	CmdProposeText = &cobra.Command{
+		Use:   "propose-text",
+		Short: "Propose a decision without on-chain execution, described by an off-chain document",
+		RunE:  cmdProposeText,
+	}
+	CmdProposeAddValidator = &cobra.Command{
+		Use:   "propose-add-validator",
+		Short: "Propose to admit a declared candidate to the validator set",
+		RunE:  cmdProposeAddValidator,
+	}
+	CmdProposeRemoveValidator = &cobra.Command{
+		Use:   "propose-remove-validator",
+		Short: "Propose to evict a candidate from the validator set",
+		RunE:  cmdProposeRemoveValidator,
+	}
+	CmdProposeGrant = &cobra.Command{
+		Use:   "propose-grant",
+		Short: "Propose to pay out an amount in tranches over a schedule",
+		RunE:  cmdProposeGrant,
+	}
+	CmdProposeCancelGrant = &cobra.Command{
+		Use:   "propose-cancel-grant",
+		Short: "Propose to cancel an active grant and claw back the amount not paid out yet",
+		RunE:  cmdProposeCancelGrant,
+	}
+	CmdProposeContractCall = &cobra.Command{
+		Use:   "propose-contract-call",
+		Short: "Propose to call a contract from the governance account",
+		RunE:  cmdProposeContractCall,
+	}
+	CmdProposeEmergencyPause = &cobra.Command{
+		Use:   "propose-emergency-pause",
+		Short: "Propose to pause the EVM transactions, or the calls to some contracts",
+		RunE:  cmdProposeEmergencyPause,
+	}
+	CmdProposeUnpause = &cobra.Command{
+		Use:   "propose-unpause",
+		Short: "Propose to lift the active emergency pause",
+		RunE:  cmdProposeUnpause,
+	}
+	CmdProposeConsensusParams = &cobra.Command{
+		Use:   "propose-consensus-params",
+		Short: "Propose to change the tendermint consensus params",
+		RunE:  cmdProposeConsensusParams,
+	}
+	CmdVote = &cobra.Command{
+		Use:   "vote",
+		Short: "Vote on a pending proposal",
+		RunE:  cmdVote,
+	}
+	CmdCancelProposal = &cobra.Command{
+		Use:   "cancel-proposal",
+		Short: "Allows the proposer to cancel a pending proposal",
+		RunE:  cmdCancelProposal,
+	}
+	CmdDelegateVote = &cobra.Command{
+		Use:   "delegate-vote",
+		Short: "Delegate the governance voting power to another validator",
+		RunE:  cmdDelegateVote,
+	}
+	CmdRevokeVoteDelegation = &cobra.Command{
+		Use:   "revoke-vote-delegation",
+		Short: "Revoke the delegation of the governance voting power",
+		RunE:  cmdRevokeVoteDelegation,
+	}
+)
+
+func init() {
+
+	// define the flags
+	fsReason := flag.NewFlagSet("", flag.ContinueOnError)
+	fsReason.String(FlagReason, "", "reason of the proposal")
+
+	fsExpire := flag.NewFlagSet("", flag.ContinueOnError)
+	fsExpire.Int64(FlagExpireTimestamp, 0, "timestamp at which the proposal expires")
+	fsExpire.Int64(FlagExpireBlockHeight, 0, "block height at which the proposal expires")
+
+	fsTimelock := flag.NewFlagSet("", flag.ContinueOnError)
+	fsTimelock.Int64(FlagExecutionDelay, 0, "number of blocks the execution is delayed after the approval, defaults to the proposal_execution_delay param")
+	fsTimelock.Int64(FlagActivationHeight, 0, "block height at which the approved proposal is executed")
+
+	fsExpireHeight := flag.NewFlagSet("", flag.ContinueOnError)
+	fsExpireHeight.Int64(FlagExpireBlockHeight, 0, "block height at which the proposal takes effect")
+
+	fsTransfer := flag.NewFlagSet("", flag.ContinueOnError)
+	fsTransfer.String(FlagTransferFrom, "", "account the fund is transferred from")
+	fsTransfer.String(FlagTransferTo, "", "account the fund is transferred to")
+	fsTransfer.String(FlagAmount, "", "amount of CMTs in wei")
+
+	fsParam := flag.NewFlagSet("", flag.ContinueOnError)
+	fsParam.String(FlagName, "", "name of the parameter")
+	fsParam.String(FlagValue, "", "new value of the parameter")
+	fsParam.String(FlagParams, "", `several parameters changed together, encoded in json: [{"name":"gas_price","value":"2000000000"}, ...]`)
+
+	fsRelease := flag.NewFlagSet("", flag.ContinueOnError)
+	fsRelease.String(FlagName, "", "name of the library or program")
+	fsRelease.String(FlagVersion, "", "version to be deployed")
+	fsRelease.String(FlagFileUrl, "", "download urls of the release, encoded in json")
+	fsRelease.String(FlagMd5, "", "md5 checksums of the release, encoded in json, only accepted for legacy releases")
+	fsRelease.String(FlagSha256, "", "sha256 digests of the release, encoded in json")
+	fsRelease.String(FlagSignature, "", "ed25519 signatures of the sha256 digests by a publisher key, encoded in json")
+
+	fsRetire := flag.NewFlagSet("", flag.ContinueOnError)
+	fsRetire.String(FlagPreservedValidators, "", "comma separated public keys of the validators kept until the end")
+
+	fsText := flag.NewFlagSet("", flag.ContinueOnError)
+	fsText.String(FlagTitle, "", "title of the proposal")
+	fsText.String(FlagDescription, "", "description of the proposal")
+	fsText.String(FlagDocumentUrl, "", "url of the off-chain document")
+	fsText.String(FlagDocumentHash, "", "hash of the off-chain document content")
+
+	fsPid := flag.NewFlagSet("", flag.ContinueOnError)
+	fsPid.String(FlagProposalId, "", "proposal ID")
+
+	fsVote := flag.NewFlagSet("", flag.ContinueOnError)
+	fsVote.String(FlagAnswer, "", "Y, N, A (abstain) or V (no with veto)")
+
+	// add the flags
+	CmdProposeTransferFund.Flags().AddFlagSet(fsTransfer)
+	CmdProposeTransferFund.Flags().AddFlagSet(fsReason)
+	CmdProposeTransferFund.Flags().AddFlagSet(fsExpire)
+	CmdProposeTransferFund.Flags().AddFlagSet(fsTimelock)
+
+	CmdProposeChangeParam.Flags().AddFlagSet(fsParam)
+	CmdProposeChangeParam.Flags().AddFlagSet(fsReason)
+	CmdProposeChangeParam.Flags().AddFlagSet(fsExpire)
+	CmdProposeChangeParam.Flags().AddFlagSet(fsTimelock)
+
+	CmdProposeDeployLibEni.Flags().AddFlagSet(fsRelease)
+	CmdProposeDeployLibEni.Flags().AddFlagSet(fsReason)
+	CmdProposeDeployLibEni.Flags().AddFlagSet(fsExpire)
+
+	CmdProposeRetireProgram.Flags().AddFlagSet(fsRetire)
+	CmdProposeRetireProgram.Flags().AddFlagSet(fsReason)
+	CmdProposeRetireProgram.Flags().AddFlagSet(fsExpireHeight)
+
+	CmdProposeUpgradeProgram.Flags().AddFlagSet(fsRelease)
+	CmdProposeUpgradeProgram.Flags().AddFlagSet(fsReason)
+	CmdProposeUpgradeProgram.Flags().AddFlagSet(fsExpireHeight)
+
+	CmdProposeText.Flags().AddFlagSet(fsText)
+	CmdProposeText.Flags().AddFlagSet(fsExpire)
+
+	fsCandidate := flag.NewFlagSet("", flag.ContinueOnError)
+	fsCandidate.String(FlagCandidate, "", "address of the candidate")
+
+	CmdProposeAddValidator.Flags().AddFlagSet(fsCandidate)
+	CmdProposeAddValidator.Flags().AddFlagSet(fsReason)
+	CmdProposeAddValidator.Flags().AddFlagSet(fsExpire)
+
+	CmdProposeRemoveValidator.Flags().AddFlagSet(fsCandidate)
+	CmdProposeRemoveValidator.Flags().AddFlagSet(fsReason)
+	CmdProposeRemoveValidator.Flags().AddFlagSet(fsExpire)
+
+	fsGrant := flag.NewFlagSet("", flag.ContinueOnError)
+	fsGrant.String(FlagGrantFrom, "", "account the grant is escrowed from")
+	fsGrant.String(FlagGrantTo, "", "recipient of the grant")
+	fsGrant.String(FlagAmount, "", "total amount of CMTs in wei")
+	fsGrant.Int64(FlagTranches, 1, "number of tranches the amount is paid out in")
+	fsGrant.Int64(FlagInterval, 1, "number of blocks between two tranches")
+
+	CmdProposeGrant.Flags().AddFlagSet(fsGrant)
+	CmdProposeGrant.Flags().AddFlagSet(fsReason)
+	CmdProposeGrant.Flags().AddFlagSet(fsExpire)
+
+	CmdProposeCancelGrant.Flags().String(FlagGrantId, "", "ID of the grant proposal")
+	CmdProposeCancelGrant.Flags().AddFlagSet(fsReason)
+	CmdProposeCancelGrant.Flags().AddFlagSet(fsExpire)
+
+	fsCall := flag.NewFlagSet("", flag.ContinueOnError)
+	fsCall.String(FlagCallTo, "", "address of the contract")
+	fsCall.String(FlagValue, "0", "amount of CMTs in wei sent with the call")
+	fsCall.String(FlagCallData, "", "hex encoded calldata")
+	fsCall.Int64(FlagGas, 0, "gas limit of the call, no more than the contract_call_proposal_max_gas param")
+
+	CmdProposeContractCall.Flags().AddFlagSet(fsCall)
+	CmdProposeContractCall.Flags().AddFlagSet(fsReason)
+	CmdProposeContractCall.Flags().AddFlagSet(fsExpire)
+	CmdProposeContractCall.Flags().AddFlagSet(fsTimelock)
+
+	CmdProposeEmergencyPause.Flags().String(FlagContracts, "", "comma separated addresses of the contracts the pause is limited to")
+	CmdProposeEmergencyPause.Flags().Int64(FlagDuration, 0, "number of blocks the pause lasts, defaults to the emergency_pause_duration param")
+	CmdProposeEmergencyPause.Flags().AddFlagSet(fsReason)
+	CmdProposeEmergencyPause.Flags().AddFlagSet(fsExpire)
+
+	CmdProposeUnpause.Flags().AddFlagSet(fsReason)
+	CmdProposeUnpause.Flags().AddFlagSet(fsExpire)
+
+	fsConsensus := flag.NewFlagSet("", flag.ContinueOnError)
+	fsConsensus.Int64(FlagBlockMaxBytes, 0, "maximum size of a block in bytes")
+	fsConsensus.Int64(FlagBlockMaxTxs, 0, "maximum number of txs in a block")
+	fsConsensus.Int64(FlagBlockMaxGas, 0, "maximum gas of a block, -1 for unlimited")
+	fsConsensus.Int64(FlagTxMaxBytes, 0, "maximum size of a tx in bytes")
+	fsConsensus.Int64(FlagTxMaxGas, 0, "maximum gas of a tx, -1 for unlimited")
+	fsConsensus.Int64(FlagBlockPartSizeBytes, 0, "size of the parts a block is gossiped in")
+
+	CmdProposeConsensusParams.Flags().AddFlagSet(fsConsensus)
+	CmdProposeConsensusParams.Flags().AddFlagSet(fsReason)
+	CmdProposeConsensusParams.Flags().AddFlagSet(fsExpire)
+	CmdProposeConsensusParams.Flags().AddFlagSet(fsTimelock)
+
+	CmdVote.Flags().AddFlagSet(fsPid)
+	CmdVote.Flags().AddFlagSet(fsVote)
+
+	CmdCancelProposal.Flags().AddFlagSet(fsPid)
+
+	CmdDelegateVote.Flags().String(FlagDelegatee, "", "owner address of the validator voting on behalf of the sender")
+}
+
+func cmdProposeTransferFund(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(viper.GetString(FlagTransferFrom)) {
+		return fmt.Errorf("please enter the source account using --transfer-from")
+	}
+	if !common.IsHexAddress(viper.GetString(FlagTransferTo)) {
+		return fmt.Errorf("please enter the destination account using --transfer-to")
+	}
+	amount, ok := new(big.Int).SetString(viper.GetString(FlagAmount), 10)
+	if !ok || amount.Sign() <= 0 {
+		return fmt.Errorf("please enter a valid amount using --amount")
+	}
+
+	from := common.HexToAddress(viper.GetString(FlagTransferFrom))
+	to := common.HexToAddress(viper.GetString(FlagTransferTo))
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+	executionDelay, activationBlockHeight := getTimelock(cmd)
+
+	tx := governance.NewTxTransferFundPropose(&from, &to, amount.String(), viper.GetString(FlagReason), expireTimestamp, expireBlockHeight, executionDelay, activationBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeChangeParam(cmd *cobra.Command, args []string) error {
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+	executionDelay, activationBlockHeight := getTimelock(cmd)
+
+	if params := viper.GetString(FlagParams); !utils.IsBlank(params) {
+		var pcs []governance.ParamChange
+		if err := json.Unmarshal([]byte(params), &pcs); err != nil || len(pcs) == 0 {
+			return fmt.Errorf("please enter a json list of name/value pairs using --params")
+		}
+		tx := governance.NewTxChangeParamsPropose(pcs, viper.GetString(FlagReason), expireTimestamp, expireBlockHeight, executionDelay, activationBlockHeight)
+		return txcmd.DoTx(tx)
+	}
+
+	name := viper.GetString(FlagName)
+	if utils.IsBlank(name) {
+		return fmt.Errorf("please enter the parameter name using --name, or several parameters using --params")
+	}
+
+	tx := governance.NewTxChangeParamPropose(name, viper.GetString(FlagValue), viper.GetString(FlagReason), expireTimestamp, expireBlockHeight, executionDelay, activationBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeDeployLibEni(cmd *cobra.Command, args []string) error {
+	name, version, fileUrl, sha256, signature, err := getRelease()
+	if err != nil {
+		return err
+	}
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+
+	tx := governance.NewTxDeployLibEniPropose(name, version, fileUrl, viper.GetString(FlagMd5), sha256, signature, viper.GetString(FlagReason), expireTimestamp, expireBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeRetireProgram(cmd *cobra.Command, args []string) error {
+	_, expireBlockHeight := getExpire(cmd)
+	if expireBlockHeight == nil {
+		return fmt.Errorf("please enter the retired block height using --expire-block-height")
+	}
+
+	tx := governance.NewTxRetireProgramPropose(viper.GetString(FlagPreservedValidators), viper.GetString(FlagReason), expireBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeUpgradeProgram(cmd *cobra.Command, args []string) error {
+	name, version, fileUrl, sha256, signature, err := getRelease()
+	if err != nil {
+		return err
+	}
+	_, expireBlockHeight := getExpire(cmd)
+	if expireBlockHeight == nil {
+		return fmt.Errorf("please enter the upgrade block height using --expire-block-height")
+	}
+
+	tx := governance.NewTxUpgradeProgramPropose(name, version, fileUrl, viper.GetString(FlagMd5), sha256, signature, viper.GetString(FlagReason), expireBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeText(cmd *cobra.Command, args []string) error {
+	title := viper.GetString(FlagTitle)
+	if utils.IsBlank(title) {
+		return fmt.Errorf("please enter the title using --title")
+	}
+	documentUrl := viper.GetString(FlagDocumentUrl)
+	documentHash := viper.GetString(FlagDocumentHash)
+	if !utils.IsBlank(documentUrl) && utils.IsBlank(documentHash) {
+		return fmt.Errorf("please enter the hash of the document using --document-hash")
+	}
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+
+	tx := governance.NewTxTextPropose(title, viper.GetString(FlagDescription), documentUrl, documentHash, expireTimestamp, expireBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeAddValidator(cmd *cobra.Command, args []string) error {
+	candidate, err := getCandidate()
+	if err != nil {
+		return err
+	}
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+
+	tx := governance.NewTxAddValidatorPropose(&candidate, viper.GetString(FlagReason), expireTimestamp, expireBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeRemoveValidator(cmd *cobra.Command, args []string) error {
+	candidate, err := getCandidate()
+	if err != nil {
+		return err
+	}
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+
+	tx := governance.NewTxRemoveValidatorPropose(&candidate, viper.GetString(FlagReason), expireTimestamp, expireBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeGrant(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(viper.GetString(FlagGrantFrom)) {
+		return fmt.Errorf("please enter the source account using --grant-from")
+	}
+	if !common.IsHexAddress(viper.GetString(FlagGrantTo)) {
+		return fmt.Errorf("please enter the recipient using --grant-to")
+	}
+	amount, ok := new(big.Int).SetString(viper.GetString(FlagAmount), 10)
+	if !ok || amount.Sign() <= 0 {
+		return fmt.Errorf("please enter a valid amount using --amount")
+	}
+	tranches, interval := viper.GetInt64(FlagTranches), viper.GetInt64(FlagInterval)
+	if tranches < 1 || interval < 1 {
+		return fmt.Errorf("please enter a positive number of tranches using --tranches, and a positive interval using --interval")
+	}
+
+	from := common.HexToAddress(viper.GetString(FlagGrantFrom))
+	to := common.HexToAddress(viper.GetString(FlagGrantTo))
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+
+	tx := governance.NewTxGrantPropose(&from, &to, amount.String(), tranches, interval, viper.GetString(FlagReason), expireTimestamp, expireBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeCancelGrant(cmd *cobra.Command, args []string) error {
+	grantId := viper.GetString(FlagGrantId)
+	if utils.IsBlank(grantId) {
+		return fmt.Errorf("please enter the ID of the grant proposal using --grant-id")
+	}
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+
+	tx := governance.NewTxCancelGrantPropose(grantId, viper.GetString(FlagReason), expireTimestamp, expireBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeContractCall(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(viper.GetString(FlagCallTo)) {
+		return fmt.Errorf("please enter the contract address using --to")
+	}
+	value, ok := new(big.Int).SetString(viper.GetString(FlagValue), 10)
+	if !ok || value.Sign() < 0 {
+		return fmt.Errorf("please enter a valid value using --value")
+	}
+	data := viper.GetString(FlagCallData)
+	if data != "" {
+		if _, err := hexutil.Decode(data); err != nil {
+			return fmt.Errorf("please enter the 0x prefixed hex encoded calldata using --data")
+		}
+	}
+	gas := viper.GetInt64(FlagGas)
+	if gas <= 0 {
+		return fmt.Errorf("please enter the gas limit using --gas")
+	}
+
+	to := common.HexToAddress(viper.GetString(FlagCallTo))
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+	executionDelay, activationBlockHeight := getTimelock(cmd)
+
+	tx := governance.NewTxContractCallPropose(&to, value.String(), data, uint64(gas), viper.GetString(FlagReason), expireTimestamp, expireBlockHeight, executionDelay, activationBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeEmergencyPause(cmd *cobra.Command, args []string) error {
+	var contracts []common.Address
+	if s := viper.GetString(FlagContracts); !utils.IsBlank(s) {
+		for _, addr := range strings.Split(s, ",") {
+			addr = strings.TrimSpace(addr)
+			if !common.IsHexAddress(addr) {
+				return fmt.Errorf("please enter valid contract addresses using --contracts")
+			}
+			contracts = append(contracts, common.HexToAddress(addr))
+		}
+	}
+	duration := viper.GetInt64(FlagDuration)
+	if duration < 0 {
+		return fmt.Errorf("please enter a positive duration using --duration")
+	}
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+
+	tx := governance.NewTxEmergencyPausePropose(contracts, duration, viper.GetString(FlagReason), expireTimestamp, expireBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeUnpause(cmd *cobra.Command, args []string) error {
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+
+	tx := governance.NewTxUnpausePropose(viper.GetString(FlagReason), expireTimestamp, expireBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeConsensusParams(cmd *cobra.Command, args []string) error {
+	// only the params given explicitly are changed
+	getParam := func(name string) *int64 {
+		if !cmd.Flags().Changed(name) {
+			return nil
+		}
+		v := viper.GetInt64(name)
+		return &v
+	}
+	content := &governance.ConsensusParamsContent{
+		BlockMaxBytes:      getParam(FlagBlockMaxBytes),
+		BlockMaxTxs:        getParam(FlagBlockMaxTxs),
+		BlockMaxGas:        getParam(FlagBlockMaxGas),
+		TxMaxBytes:         getParam(FlagTxMaxBytes),
+		TxMaxGas:           getParam(FlagTxMaxGas),
+		BlockPartSizeBytes: getParam(FlagBlockPartSizeBytes),
+		Reason:             viper.GetString(FlagReason),
+	}
+	if content.BlockMaxBytes == nil && content.BlockMaxTxs == nil && content.BlockMaxGas == nil &&
+		content.TxMaxBytes == nil && content.TxMaxGas == nil && content.BlockPartSizeBytes == nil {
+		return fmt.Errorf("please enter at least one of the consensus params to change")
+	}
+	expireTimestamp, expireBlockHeight := getExpire(cmd)
+	executionDelay, activationBlockHeight := getTimelock(cmd)
+
+	tx := governance.NewTxConsensusParamsPropose(content, expireTimestamp, expireBlockHeight, executionDelay, activationBlockHeight)
+	return txcmd.DoTx(tx)
+}
+
+func cmdVote(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if utils.IsBlank(pid) {
+		return fmt.Errorf("please enter the proposal ID using --proposal-id")
+	}
+	answer := viper.GetString(FlagAnswer)
+	if utils.IsBlank(answer) {
+		return fmt.Errorf("please enter your answer using --answer")
+	}
+
+	tx := governance.NewTxVote(pid, answer)
+	return txcmd.DoTx(tx)
+}
+
+func cmdCancelProposal(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if utils.IsBlank(pid) {
+		return fmt.Errorf("please enter the proposal ID using --proposal-id")
+	}
+
+	tx := governance.NewTxCancelProposal(pid)
+	return txcmd.DoTx(tx)
+}
+
+func cmdDelegateVote(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(viper.GetString(FlagDelegatee)) {
+		return fmt.Errorf("please enter the owner address of the delegatee using --delegatee")
+	}
+
+	delegatee := common.HexToAddress(viper.GetString(FlagDelegatee))
+	tx := governance.NewTxDelegateVote(&delegatee)
+	return txcmd.DoTx(tx)
+}
+
+func cmdRevokeVoteDelegation(cmd *cobra.Command, args []string) error {
+	tx := governance.NewTxRevokeVoteDelegation()
+	return txcmd.DoTx(tx)
+}
+
+// getExpire returns the expiry flags which were explicitly set, nil otherwise
+func getExpire(cmd *cobra.Command) (expireTimestamp, expireBlockHeight *int64) {
+	if cmd.Flags().Changed(FlagExpireTimestamp) {
+		ts := viper.GetInt64(FlagExpireTimestamp)
+		expireTimestamp = &ts
+	}
+	if cmd.Flags().Changed(FlagExpireBlockHeight) {
+		height := viper.GetInt64(FlagExpireBlockHeight)
+		expireBlockHeight = &height
+	}
+	return
+}
+
+// getTimelock returns the timelock flags which were explicitly set, nil otherwise
+func getTimelock(cmd *cobra.Command) (executionDelay, activationBlockHeight *int64) {
+	if cmd.Flags().Changed(FlagExecutionDelay) {
+		delay := viper.GetInt64(FlagExecutionDelay)
+		executionDelay = &delay
+	}
+	if cmd.Flags().Changed(FlagActivationHeight) {
+		height := viper.GetInt64(FlagActivationHeight)
+		activationBlockHeight = &height
+	}
+	return
+}
+
+func getRelease() (name, version, fileUrl, sha256, signature string, err error) {
+	name = viper.GetString(FlagName)
+	if utils.IsBlank(name) {
+		return "", "", "", "", "", fmt.Errorf("please enter the name using --name")
+	}
+	version = viper.GetString(FlagVersion)
+	if utils.IsBlank(version) {
+		return "", "", "", "", "", fmt.Errorf("please enter the version using --version")
+	}
+	fileUrl = viper.GetString(FlagFileUrl)
+	if utils.IsBlank(fileUrl) {
+		return "", "", "", "", "", fmt.Errorf("please enter the download urls using --file-url")
+	}
+	sha256 = viper.GetString(FlagSha256)
+	if utils.IsBlank(sha256) {
+		return "", "", "", "", "", fmt.Errorf("please enter the sha256 digests using --sha256")
+	}
+	signature = viper.GetString(FlagSignature)
+	if utils.IsBlank(signature) {
+		return "", "", "", "", "", fmt.Errorf("please enter the signatures of the digests using --signature")
+	}
+	return
+}
+
+func getCandidate() (candidate common.Address, err error) {
+	if !common.IsHexAddress(viper.GetString(FlagCandidate)) {
+		return candidate, fmt.Errorf("please enter the address of the candidate using --candidate")
+	}
+	return common.HexToAddress(viper.GetString(FlagCandidate)), nil
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// the txs are rejected before they are signed and broadcast if a flag is missing or invalid
+func TestTxFlagsValidation(t *testing.T) {
+	release := map[string]string{
+		FlagName:      "travis",
+		FlagVersion:   "v1.0.0",
+		FlagFileUrl:   `{"linux":["http://example.com/travis"]}`,
+		FlagSha256:    `{"linux":"0x00"}`,
+		FlagSignature: `{"linux":"0x00"}`,
+	}
+	without := func(flags map[string]string, name string) map[string]string {
+		ret := make(map[string]string)
+		for k, v := range flags {
+			if k != name {
+				ret[k] = v
+			}
+		}
+		return ret
+	}
+
+	tests := map[string]struct {
+		run   func(cmd *cobra.Command, args []string) error
+		flags map[string]string
+		want  string
+	}{
+		"transfer without source": {cmdProposeTransferFund,
+			map[string]string{FlagTransferTo: "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc", FlagAmount: "1"}, "--transfer-from"},
+		"transfer of a negative amount": {cmdProposeTransferFund,
+			map[string]string{FlagTransferFrom: "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc", FlagTransferTo: "0x77beb894fc9b0ed41231e51f128a347043960a9d", FlagAmount: "-1"}, "--amount"},
+		"param without name":       {cmdProposeChangeParam, nil, "--name"},
+		"params not a json list":   {cmdProposeChangeParam, map[string]string{FlagParams: `{"name":"gas_price"}`}, "--params"},
+		"params empty list":        {cmdProposeChangeParam, map[string]string{FlagParams: `[]`}, "--params"},
+		"libeni without signature": {cmdProposeDeployLibEni, without(release, FlagSignature), "--signature"},
+		"upgrade without version":  {cmdProposeUpgradeProgram, without(release, FlagVersion), "--version"},
+		"upgrade without height":   {cmdProposeUpgradeProgram, release, "--expire-block-height"},
+		"retire without height":    {cmdProposeRetireProgram, nil, "--expire-block-height"},
+		"vote without proposal":    {cmdVote, map[string]string{FlagAnswer: "Y"}, "--proposal-id"},
+		"vote without answer":      {cmdVote, map[string]string{FlagProposalId: "1"}, "--answer"},
+	}
+
+	for name, tt := range tests {
+		viper.Reset()
+		for k, v := range tt.flags {
+			viper.Set(k, v)
+		}
+		err := tt.run(&cobra.Command{}, nil)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %v, want an error about %s", name, err, tt.want)
+		}
+	}
+	viper.Reset()
+}
+
+// only the timelock flags given explicitly override the defaults, an explicit zero included
+func TestGetTimelock(t *testing.T) {
+	defer viper.Reset()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Int64(FlagExecutionDelay, 0, "")
+	cmd.Flags().Int64(FlagActivationHeight, 0, "")
+	viper.BindPFlags(cmd.Flags())
+
+	if delay, height := getTimelock(cmd); delay != nil || height != nil {
+		t.Fatalf("got %v, %v without the flags, want nil", delay, height)
+	}
+
+	cmd.Flags().Set(FlagExecutionDelay, "0")
+	delay, height := getTimelock(cmd)
+	if delay == nil || *delay != 0 || height != nil {
+		t.Fatalf("got %v, %v, want an explicit zero delay", delay, height)
+	}
+
+	cmd.Flags().Set(FlagActivationHeight, "120")
+	if _, height = getTimelock(cmd); height == nil || *height != 120 {
+		t.Fatalf("got activation height %v, want 120", height)
+	}
+}
@@ -0,0 +1,188 @@
+package governance
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"golang.org/x/crypto/ripemd160"
+
+	"github.com/vangjvn/devchain/types"
+)
+
+// activeConsensusParams caches the consensus params in effect, i.e. the ones of the genesis
+// with the updates of the approved consensus_params proposals applied over them.
+var (
+	activeConsensusParams *tmtypes.ConsensusParams
+	consensusParamsMtx    sync.RWMutex
+)
+
+// GetConsensusParams returns the consensus params in effect
+func GetConsensusParams() tmtypes.ConsensusParams {
+	consensusParamsMtx.RLock()
+	defer consensusParamsMtx.RUnlock()
+	if activeConsensusParams == nil {
+		return *tmtypes.DefaultConsensusParams()
+	}
+	return *activeConsensusParams
+}
+
+func setConsensusParams(cp tmtypes.ConsensusParams) {
+	consensusParamsMtx.Lock()
+	defer consensusParamsMtx.Unlock()
+	activeConsensusParams = &cp
+}
+
+// LoadConsensusParams restores the consensus params in effect when the node starts,
+// genesis is the consensus params of the genesis file, the tendermint defaults are used if it is nil
+func LoadConsensusParams(genesis *tmtypes.ConsensusParams) {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	var params string
+	err = tx.QueryRow("select params from governance_consensus_params where status = ? order by block_height desc, proposal_id desc limit 1", CONSENSUS_PARAMS_STATUS_APPLIED).Scan(&params)
+	switch {
+	case err == sql.ErrNoRows:
+		if genesis == nil {
+			genesis = tmtypes.DefaultConsensusParams()
+		}
+		setConsensusParams(*genesis)
+		return
+	case err != nil:
+		panic(err)
+	}
+
+	var cp tmtypes.ConsensusParams
+	if err = json.Unmarshal([]byte(params), &cp); err != nil {
+		panic(err)
+	}
+	setConsensusParams(cp)
+}
+
+// scheduledConsensusParams is the update of an approved consensus_params proposal scheduled at the block height,
+// Params is the consensus params in effect once it is applied
+type scheduledConsensusParams struct {
+	ProposalId  string
+	BlockHeight int64
+	Status      string
+	Params      string
+}
+
+func (s *scheduledConsensusParams) Hash() []byte {
+	var excludedFields []string
+	bs := types.Hash(s, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
+// ScheduleConsensusParams schedules the update of an approved consensus_params proposal
+// to be returned by the EndBlock of the block height
+func ScheduleConsensusParams(pid string, blockHeight int64) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("insert into governance_consensus_params(proposal_id, block_height, status, hash) values(?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	s := &scheduledConsensusParams{pid, blockHeight, CONSENSUS_PARAMS_STATUS_PENDING, ""}
+	_, err = stmt.Exec(s.ProposalId, s.BlockHeight, s.Status, common.Bytes2Hex(s.Hash()))
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+// ApplyConsensusParams applies the updates scheduled at the block height over the consensus params in effect.
+// It returns the update of the EndBlock response, or nil if nothing is changed at the block height.
+func ApplyConsensusParams(blockHeight int64) *abci.ConsensusParams {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	rows, err := txWrapper.tx.Query("select proposal_id, block_height from governance_consensus_params where status = ? and block_height <= ? order by block_height, proposal_id", CONSENSUS_PARAMS_STATUS_PENDING, blockHeight)
+	if err != nil {
+		panic(err)
+	}
+	var scheduled []*scheduledConsensusParams
+	for rows.Next() {
+		s := &scheduledConsensusParams{}
+		if err = rows.Scan(&s.ProposalId, &s.BlockHeight); err != nil {
+			panic(err)
+		}
+		scheduled = append(scheduled, s)
+	}
+	if err = rows.Err(); err != nil {
+		panic(err)
+	}
+	rows.Close()
+
+	cp := GetConsensusParams()
+	applied := false
+	for _, s := range scheduled {
+		pid := s.ProposalId
+		status, detail := CONSENSUS_PARAMS_STATUS_FAILED, ""
+		content := &ConsensusParamsContent{}
+		if !content.Load(txWrapper.tx, pid) {
+			detail = "missing proposal detail"
+		} else if next, err := content.apply(cp); err != nil {
+			detail = err.Error()
+		} else {
+			cp, applied = next, true
+			status = CONSENSUS_PARAMS_STATUS_APPLIED
+		}
+		s.Status = status
+		updateConsensusParams(txWrapper.tx, s, cp)
+		saveEvent(txWrapper.tx, pid, EVENT_CONSENSUS_PARAMS, "", status+" "+detail, blockHeight)
+	}
+
+	if !applied {
+		return nil
+	}
+	setConsensusParams(cp)
+	return toABCIConsensusParams(cp)
+}
+
+func updateConsensusParams(tx *sql.Tx, s *scheduledConsensusParams, cp tmtypes.ConsensusParams) {
+	stmt, err := tx.Prepare("update governance_consensus_params set status = ?, params = ?, hash = ? where proposal_id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	params, _ := json.Marshal(cp)
+	s.Params = string(params)
+	_, err = stmt.Exec(s.Status, s.Params, common.Bytes2Hex(s.Hash()), s.ProposalId)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+// toABCIConsensusParams converts the consensus params to the update of the EndBlock response.
+// The evidence params can't be updated through the ABCI of this tendermint version.
+func toABCIConsensusParams(cp tmtypes.ConsensusParams) *abci.ConsensusParams {
+	return &abci.ConsensusParams{
+		BlockSize: &abci.BlockSize{
+			MaxBytes: int32(cp.BlockSize.MaxBytes),
+			MaxTxs:   int32(cp.BlockSize.MaxTxs),
+			MaxGas:   cp.BlockSize.MaxGas,
+		},
+		TxSize: &abci.TxSize{
+			MaxBytes: int32(cp.TxSize.MaxBytes),
+			MaxGas:   cp.TxSize.MaxGas,
+		},
+		BlockGossip: &abci.BlockGossip{
+			BlockPartSizeBytes: int32(cp.BlockGossip.BlockPartSizeBytes),
+		},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"database/sql"
+	"github.com/vangjvn/devchain/modules/stake"
 	"github.com/vangjvn/devchain/sdk/dbm"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -71,95 +72,52 @@ func SaveProposal(pp *Proposal) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
 
-	stmt, err := txWrapper.tx.Prepare("insert into governance_proposal(id, type, proposer, block_height, expire_timestamp, expire_block_height, hash) values(?, ?, ?, ?, ?, ?, ?)")
+	stmt, err := txWrapper.tx.Prepare("insert into governance_proposal(id, type, proposer, block_height, expire_timestamp, expire_block_height, deposit, execution_delay, activation_block_height, hash) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		panic(err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(pp.Id, pp.Type, pp.Proposer.String(), pp.BlockHeight, pp.ExpireTimestamp, pp.ExpireBlockHeight, common.Bytes2Hex(pp.Hash()))
+	_, err = stmt.Exec(pp.Id, pp.Type, pp.Proposer.String(), pp.BlockHeight, pp.ExpireTimestamp, pp.ExpireBlockHeight, pp.Deposit, pp.ExecutionDelay, pp.ActivationBlockHeight, common.Bytes2Hex(pp.Hash()))
 	if err != nil {
 		fmt.Println(err)
 		panic(err)
 	}
 
-	switch pp.Type {
-	case TRANSFER_FUND_PROPOSAL:
-		stmt1, err := txWrapper.tx.Prepare("insert into governance_transfer_fund_detail(proposal_id, from_address, to_address, amount, reason) values(?, ?, ?, ?, ?)")
-		if err != nil {
-			panic(err)
-		}
-		defer stmt1.Close()
-
-		_, err = stmt1.Exec(pp.Id, pp.Detail["from"].(*common.Address).String(), pp.Detail["to"].(*common.Address).String(), pp.Detail["amount"], pp.Detail["reason"])
-		if err != nil {
-			fmt.Println(err)
-			panic(err)
-		}
-	case CHANGE_PARAM_PROPOSAL:
-		stmt1, err := txWrapper.tx.Prepare("insert into governance_change_param_detail(proposal_id, param_name, param_value,  reason) values(?, ?, ?, ?)")
-		if err != nil {
-			panic(err)
-		}
-		defer stmt1.Close()
-
-		_, err = stmt1.Exec(pp.Id, pp.Detail["name"], pp.Detail["value"], pp.Detail["reason"])
-		if err != nil {
-			fmt.Println(err)
-			panic(err)
-		}
-	case DEPLOY_LIBENI_PROPOSAL:
-		stmt1, err := txWrapper.tx.Prepare("insert into governance_deploy_libeni_detail(proposal_id, name, version, fileurl, md5, reason, status) values(?, ?, ?, ?, ?, ?, ?)")
-		if err != nil {
-			panic(err)
-		}
-		defer stmt1.Close()
-
-		_, err = stmt1.Exec(pp.Id, pp.Detail["name"], pp.Detail["version"], pp.Detail["fileurl"], pp.Detail["md5"], pp.Detail["reason"], pp.Detail["status"])
-		if err != nil {
-			fmt.Println(err)
-			panic(err)
-		}
-	case RETIRE_PROGRAM_PROPOSAL:
-		stmt1, err := txWrapper.tx.Prepare("insert into governance_retire_program_detail(proposal_id, retired_version, preserved_validators, reason, status) values(?, ?, ?, ?, ?)")
-		if err != nil {
-			panic(err)
-		}
-		defer stmt1.Close()
+	saveEvent(txWrapper.tx, pp.Id, EVENT_PROPOSAL_CREATED, pp.Proposer.String(), pp.Type, pp.BlockHeight)
+	saveValidatorSnapshot(txWrapper.tx, pp.Id, stake.GetCandidates().Validators())
 
-		_, err = stmt1.Exec(pp.Id, pp.Detail["retired_version"], pp.Detail["preserved_validators"], pp.Detail["reason"], pp.Detail["status"])
-		if err != nil {
-			fmt.Println(err)
-			panic(err)
-		}
-	case UPGRADE_PROGRAM_PROPOSAL:
-		stmt1, err := txWrapper.tx.Prepare("insert into governance_upgrade_program_detail(proposal_id, retired_version, name, version, fileurl, md5, reason) values(?, ?, ?, ?, ?, ?, ?)")
-		if err != nil {
-			panic(err)
-		}
-		defer stmt1.Close()
-
-		_, err = stmt1.Exec(pp.Id, pp.Detail["retired_version"], pp.Detail["name"], pp.Detail["version"], pp.Detail["fileurl"], pp.Detail["md5"], pp.Detail["reason"])
-		if err != nil {
-			fmt.Println(err)
-			panic(err)
-		}
-	}
+	pp.Content.Save(txWrapper.tx, pp.Id)
 }
 
 func GetProposalById(pid string) *Proposal {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
 
-	stmt, err := txWrapper.tx.Prepare("select type, proposer, block_height, expire_timestamp, expire_block_height, hash, result, result_msg, result_block_height from governance_proposal where id = ?")
+	return getProposalById(txWrapper.tx, pid)
+}
+
+func QueryProposalById(pid string) *Proposal {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	return getProposalById(tx, pid)
+}
+
+func getProposalById(tx *sql.Tx, pid string) *Proposal {
+	stmt, err := tx.Prepare("select type, proposer, block_height, expire_timestamp, expire_block_height, hash, result, result_msg, result_block_height, deposit, execution_delay, activation_block_height, execute_block_height from governance_proposal where id = ?")
 	if err != nil {
 		panic(err)
 	}
 	defer stmt.Close()
 
-	var ptype, proposer, result, resultMsg, hash string
+	var ptype, proposer, result, resultMsg, hash, deposit string
 	var blockHeight, expireTimestamp, expireBlockHeight, resultBlockHeight int64
-	err = stmt.QueryRow(pid).Scan(&ptype, &proposer, &blockHeight, &expireTimestamp, &expireBlockHeight, &hash, &result, &resultMsg, &resultBlockHeight)
+	var executionDelay, activationBlockHeight, executeBlockHeight int64
+	err = stmt.QueryRow(pid).Scan(&ptype, &proposer, &blockHeight, &expireTimestamp, &expireBlockHeight, &hash, &result, &resultMsg, &resultBlockHeight, &deposit, &executionDelay, &activationBlockHeight, &executeBlockHeight)
 	switch {
 	case err == sql.ErrNoRows:
 		return nil
@@ -167,181 +125,61 @@ func GetProposalById(pid string) *Proposal {
 		panic(err)
 	}
 
-	prp := common.HexToAddress(proposer)
+	content := loadProposalContent(tx, ptype, pid)
+	if content == nil {
+		return nil
+	}
 
-	switch ptype {
-	case TRANSFER_FUND_PROPOSAL:
-		var fromAddr, toAddr, amount, reason string
-		stmt1, err := txWrapper.tx.Prepare("select from_address, to_address, amount, reason from governance_transfer_fund_detail where proposal_id = ?")
-		if err != nil {
-			panic(err)
-		}
-		defer stmt1.Close()
-		err = stmt1.QueryRow(pid).Scan(&fromAddr, &toAddr, &amount, &reason)
-		switch {
-		case err == sql.ErrNoRows:
-			return nil
-		case err != nil:
-			panic(err)
-		}
+	prp := common.HexToAddress(proposer)
 
-		fr := common.HexToAddress(fromAddr)
-		to := common.HexToAddress(toAddr)
+	return &Proposal{
+		pid,
+		ptype,
+		&prp,
+		blockHeight,
+		expireTimestamp,
+		expireBlockHeight,
+		result,
+		resultMsg,
+		resultBlockHeight,
+		deposit,
+		executionDelay,
+		activationBlockHeight,
+		executeBlockHeight,
+		content,
+	}
+}
 
-		return &Proposal{
-			pid,
-			ptype,
-			&prp,
-			blockHeight,
-			expireTimestamp,
-			expireBlockHeight,
-			result,
-			resultMsg,
-			resultBlockHeight,
-			map[string]interface{}{
-				"from":   &fr,
-				"to":     &to,
-				"amount": amount,
-				"reason": reason,
-			},
-		}
-	case CHANGE_PARAM_PROPOSAL:
-		var name, value, reason string
-		stmt1, err := txWrapper.tx.Prepare("select param_name, param_value, reason from governance_change_param_detail where proposal_id = ?")
-		if err != nil {
-			panic(err)
-		}
-		defer stmt1.Close()
-		err = stmt1.QueryRow(pid).Scan(&name, &value, &reason)
-		switch {
-		case err == sql.ErrNoRows:
-			return nil
-		case err != nil:
-			panic(err)
-		}
+func UpdateProposalResult(pid, result, msg string, blockHeight int64) {
+	p := GetProposalById(pid)
+	if p == nil {
+		return
+	}
 
-		return &Proposal{
-			pid,
-			ptype,
-			&prp,
-			blockHeight,
-			expireTimestamp,
-			expireBlockHeight,
-			result,
-			resultMsg,
-			resultBlockHeight,
-			map[string]interface{}{
-				"name":   name,
-				"value":  value,
-				"reason": reason,
-			},
-		}
-	case DEPLOY_LIBENI_PROPOSAL:
-		var name, version, fileurl, md5, reason, status string
-		stmt1, err := txWrapper.tx.Prepare("select name, version, fileurl, md5, reason, status from governance_deploy_libeni_detail where proposal_id = ?")
-		if err != nil {
-			panic(err)
-		}
-		defer stmt1.Close()
-		err = stmt1.QueryRow(pid).Scan(&name, &version, &fileurl, &md5, &reason, &status)
-		switch {
-		case err == sql.ErrNoRows:
-			return nil
-		case err != nil:
-			panic(err)
-		}
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
 
-		return &Proposal{
-			pid,
-			ptype,
-			&prp,
-			blockHeight,
-			expireTimestamp,
-			expireBlockHeight,
-			result,
-			resultMsg,
-			resultBlockHeight,
-			map[string]interface{}{
-				"name":    name,
-				"version": version,
-				"fileurl": fileurl,
-				"md5":     md5,
-				"reason":  reason,
-				"status":  status,
-			},
-		}
-	case RETIRE_PROGRAM_PROPOSAL:
-		var retiredVersion, preservedValidators, reason, status string
-		stmt1, err := txWrapper.tx.Prepare("select retired_version, preserved_validators, reason, status from governance_retire_program_detail where proposal_id = ?")
-		if err != nil {
-			panic(err)
-		}
-		defer stmt1.Close()
-		err = stmt1.QueryRow(pid).Scan(&retiredVersion, &preservedValidators, &reason, &status)
-		switch {
-		case err == sql.ErrNoRows:
-			return nil
-		case err != nil:
-			panic(err)
-		}
+	stmt, err := txWrapper.tx.Prepare("update governance_proposal set result = ?, result_msg = ?, result_block_height = ?, hash = ? where id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
 
-		return &Proposal{
-			pid,
-			ptype,
-			&prp,
-			blockHeight,
-			expireTimestamp,
-			expireBlockHeight,
-			result,
-			resultMsg,
-			resultBlockHeight,
-			map[string]interface{}{
-				"retired_version": retiredVersion,
-				"preserved_validators": preservedValidators,
-				"reason": reason,
-				"status": status,
-			},
-		}
-	case UPGRADE_PROGRAM_PROPOSAL:
-		var retiredVersion, name, version, fileurl, md5, reason string
-		stmt1, err := txWrapper.tx.Prepare("select retired_version, name, version, fileurl, md5, reason from governance_upgrade_program_detail where proposal_id = ?")
-		if err != nil {
-			panic(err)
-		}
-		defer stmt1.Close()
-		err = stmt1.QueryRow(pid).Scan(&retiredVersion, &name, &version, &fileurl, &md5, &reason)
-		switch {
-		case err == sql.ErrNoRows:
-			return nil
-		case err != nil:
-			panic(err)
-		}
+	p.Result = result
+	p.ResultMsg = msg
+	p.ResultBlockHeight = blockHeight
 
-		return &Proposal {
-			pid,
-			ptype,
-			&prp,
-			blockHeight,
-			expireTimestamp,
-			expireBlockHeight,
-			result,
-			resultMsg,
-			resultBlockHeight,
-			map[string]interface{}{
-				"retired_version": retiredVersion,
-				"name": name,
-				"version": version,
-				"fileurl": fileurl,
-				"md5": md5,
-				"reason": reason,
-			},
-		}
+	_, err = stmt.Exec(result, msg, blockHeight, common.Bytes2Hex(p.Hash()), pid)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
 	}
 
-	return nil
+	saveEvent(txWrapper.tx, pid, strings.ToLower(result), "", msg, blockHeight)
 }
 
-func UpdateProposalResult(pid, result, msg string, blockHeight int64) {
+// UpdateProposalQueued records an approved proposal waiting for its execute block height
+func UpdateProposalQueued(pid string, executeBlockHeight, blockHeight int64) {
 	p := GetProposalById(pid)
 	if p == nil {
 		return
@@ -350,21 +188,24 @@ func UpdateProposalResult(pid, result, msg string, blockHeight int64) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
 
-	stmt, err := txWrapper.tx.Prepare("update governance_proposal set result = ?, result_msg = ?, result_block_height = ?, hash = ? where id = ?")
+	stmt, err := txWrapper.tx.Prepare("update governance_proposal set result = ?, result_msg = ?, result_block_height = ?, execute_block_height = ?, hash = ? where id = ?")
 	if err != nil {
 		panic(err)
 	}
 	defer stmt.Close()
 
-	p.Result = result
-	p.ResultMsg = msg
+	p.Result = "Queued"
+	p.ResultMsg = ""
 	p.ResultBlockHeight = blockHeight
+	p.ExecuteBlockHeight = executeBlockHeight
 
-	_, err = stmt.Exec(result, msg, blockHeight, common.Bytes2Hex(p.Hash()), pid)
+	_, err = stmt.Exec(p.Result, p.ResultMsg, blockHeight, executeBlockHeight, common.Bytes2Hex(p.Hash()), pid)
 	if err != nil {
 		fmt.Println(err)
 		panic(err)
 	}
+
+	saveEvent(txWrapper.tx, pid, EVENT_PROPOSAL_QUEUED, "", fmt.Sprintf("execute at %d", executeBlockHeight), blockHeight)
 }
 
 func UpdateDeployLibEniStatus(pid, status string) {
@@ -384,7 +225,6 @@ func UpdateDeployLibEniStatus(pid, status string) {
 
 		_, err = stmt.Exec(status, pid)
 		if err != nil {
-			fmt.Println(err)
 			panic(err)
 		}
 	}()
@@ -405,6 +245,8 @@ func UpdateRetireProgramStatus(pid, status string) {
 		fmt.Println(err)
 		panic(err)
 	}
+
+	saveEvent(txWrapper.tx, pid, EVENT_RETIRE_STATUS, "", status, getWorkingHeight())
 }
 
 func QueryProposals() (proposals []*Proposal) {
@@ -414,43 +256,89 @@ func QueryProposals() (proposals []*Proposal) {
 	}
 	defer tx.Commit()
 
-	proposals = getProposals(tx)
+	proposals = getProposals(tx, "", nil)
 	return
 }
 
-func getProposals(tx *sql.Tx) (proposals []*Proposal) {
-	rows, err := tx.Query(`select p.id, p.type, p.proposer, p.block_height, p.expire_timestamp, p.expire_block_height, p.hash, p.result, p.result_msg, p.result_block_height,
-		case
-		when p.type = 'transfer_fund'
-		then (select printf('%s-+-%s-+-%s-+-%s', from_address, to_address, amount, reason) from governance_transfer_fund_detail where proposal_id = p.id) 
-		when p.type = 'change_param'
-		then (select printf('%s-+-%s-+-%s', param_name, param_value, reason) from governance_change_param_detail where proposal_id = p.id)
-		when p.type = 'deploy_libeni'
-		then (select printf('%s-+-%s-+-%s-+-%s-+-%s-+-%s', name, version, fileurl, md5, reason, status) from governance_deploy_libeni_detail where proposal_id = p.id)
-		when p.type = 'retire_program'
-		then (select printf('%s-+-%s-+-%s-+-%s', retired_version, preserved_validators, reason, status) from governance_retire_program_detail where proposal_id = p.id)
-		when p.type = 'upgrade_program'
-		then (select printf('%s-+-%s-+-%s-+-%s-+-%s-+-%s', retired_version, name, version, fileurl, md5, reason) from governance_upgrade_program_detail where proposal_id = p.id)
-		end as detail
-		from governance_proposal p`)
+// QueryProposalsByFilter returns a page of the proposals matching the filter, the latest first
+func QueryProposalsByFilter(filter ProposalFilter) *ProposalPage {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	cond, args := proposalConditions(filter)
+
+	var total int64
+	err = tx.QueryRow("select count(*) from governance_proposal p"+cond, args...).Scan(&total)
+	if err != nil {
+		panic(err)
+	}
+
+	page, perPage := filter.Pagination()
+	args = append(args, perPage, (page-1)*perPage)
+	proposals := getProposals(tx, cond+" order by p.block_height desc, p.id limit ? offset ?", args)
+
+	return &ProposalPage{total, page, perPage, proposals}
+}
+
+func proposalConditions(filter ProposalFilter) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+
+	if filter.Type != "" {
+		conds = append(conds, "p.type = ?")
+		args = append(args, filter.Type)
+	}
+	if filter.Status == PENDING_PROPOSAL_STATUS {
+		conds = append(conds, "p.result = ''")
+	} else if filter.Status != "" {
+		conds = append(conds, "p.result = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.Proposer != "" {
+		conds = append(conds, "p.proposer = ?")
+		args = append(args, common.HexToAddress(filter.Proposer).String())
+	}
+	if filter.FromHeight > 0 {
+		conds = append(conds, "p.block_height >= ?")
+		args = append(args, filter.FromHeight)
+	}
+	if filter.ToHeight > 0 {
+		conds = append(conds, "p.block_height <= ?")
+		args = append(args, filter.ToHeight)
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " where " + strings.Join(conds, " and "), args
+}
+
+// getProposals loads the proposals with their details, clause is appended to the query, e.g. a where clause
+func getProposals(tx *sql.Tx, clause string, args []interface{}) (proposals []*Proposal) {
+	rows, err := tx.Query("select p.id, p.type, p.proposer, p.block_height, p.expire_timestamp, p.expire_block_height, p.hash, p.result, p.result_msg, p.result_block_height, p.deposit, p.execution_delay, p.activation_block_height, p.execute_block_height from governance_proposal p"+clause, args...)
 	if err != nil {
 		fmt.Println(err)
 		panic(err)
 	}
-	defer rows.Close()
 
+	var all []*Proposal
 	for rows.Next() {
-		var id, ptype, proposer, result, resultMsg, hash, detail string
+		var id, ptype, proposer, result, resultMsg, hash, deposit string
 		var blockHeight, expireTimestamp, expireBlockHeight, resultBlockHeight int64
+		var executionDelay, activationBlockHeight, executeBlockHeight int64
 
-		err = rows.Scan(&id, &ptype, &proposer, &blockHeight, &expireTimestamp, &expireBlockHeight, &hash, &result, &resultMsg, &resultBlockHeight, &detail)
+		err = rows.Scan(&id, &ptype, &proposer, &blockHeight, &expireTimestamp, &expireBlockHeight, &hash, &result, &resultMsg, &resultBlockHeight, &deposit, &executionDelay, &activationBlockHeight, &executeBlockHeight)
 		if err != nil {
+			rows.Close()
 			panic(err)
 		}
 
 		prp := common.HexToAddress(proposer)
 
-		pp := &Proposal{
+		all = append(all, &Proposal{
 			id,
 			ptype,
 			&prp,
@@ -460,81 +348,27 @@ func getProposals(tx *sql.Tx) (proposals []*Proposal) {
 			result,
 			resultMsg,
 			resultBlockHeight,
+			deposit,
+			executionDelay,
+			activationBlockHeight,
+			executeBlockHeight,
 			nil,
-		}
-
-		d := strings.Split(detail, "-+-")
-
-		switch ptype {
-		case TRANSFER_FUND_PROPOSAL:
-			if len(d) != 4 {
-				continue
-			}
-			fromAddr := d[0]
-			toAddr := d[1]
-
-			fr := common.HexToAddress(fromAddr)
-			to := common.HexToAddress(toAddr)
-
-			pp.Detail = map[string]interface{}{
-				"from":   &fr,
-				"to":     &to,
-				"amount": d[2],
-				"reason": d[3],
-			}
-		case CHANGE_PARAM_PROPOSAL:
-			if len(d) != 3 {
-				continue
-			}
-			pp.Detail = map[string]interface{}{
-				"name":   d[0],
-				"value":  d[1],
-				"reason": d[2],
-			}
-		case DEPLOY_LIBENI_PROPOSAL:
-			if len(d) != 6 {
-				continue
-			}
-			pp.Detail = map[string]interface{}{
-				"name":    d[0],
-				"version": d[1],
-				"fileurl": d[2],
-				"md5":     d[3],
-				"reason":  d[4],
-				"status":  d[5],
-			}
-		case RETIRE_PROGRAM_PROPOSAL:
-			if len(d) != 4 {
-				continue
-			}
-			pp.Detail = map[string]interface{}{
-				"retired_version":      d[0],
-				"preserved_validators": d[1],
-				"reason":               d[2],
-				"status":               d[3],
-			}
-		case UPGRADE_PROGRAM_PROPOSAL:
-			if len(d) != 6 {
-				continue
-			}
-			pp.Detail = map[string]interface{} {
-				"retired_version": d[0],
-				"name":            d[1],
-				"version":         d[2],
-				"fileurl":         d[3],
-				"md5":             d[4],
-				"reason":          d[5],
-			}
-		}
-
-		proposals = append(proposals, pp)
+		})
 	}
 
 	err = rows.Err()
+	rows.Close()
 	if err != nil {
 		panic(err)
 	}
 
+	// the details are loaded once the rows are closed, the proposals without detail are left out
+	for _, pp := range all {
+		if pp.Content = loadProposalContent(tx, pp.Type, pp.Id); pp.Content != nil {
+			proposals = append(proposals, pp)
+		}
+	}
+
 	return
 }
 
@@ -569,7 +403,8 @@ func GetPendingProposals() (proposals []*Proposal) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
 
-	rows, err := txWrapper.tx.Query("select id, type, expire_timestamp, expire_block_height from governance_proposal p where (result = '' and type != 'retire_program' and type != 'upgrade_program') or (result = 'Approved' and type = 'deploy_libeni' and exists (select * from governance_deploy_libeni_detail d where d.proposal_id=p.id and (d.status != 'deployed' and d.status != 'failed' and d.status != 'collapsed')))")
+	// queued proposals are pending until their execute block height
+	rows, err := txWrapper.tx.Query("select id, type, case when result = 'Queued' then 0 else expire_timestamp end, case when result = 'Queued' then execute_block_height else expire_block_height end from governance_proposal p where (result = '' and type != 'retire_program' and type != 'upgrade_program') or (result = 'Approved' and type = 'deploy_libeni' and exists (select * from governance_deploy_libeni_detail d where d.proposal_id=p.id and (d.status != 'deployed' and d.status != 'failed' and d.status != 'collapsed'))) or result = 'Queued'")
 	if err != nil {
 		panic(err)
 	}
@@ -736,6 +571,8 @@ func SaveVote(vote *Vote) {
 		fmt.Println(err)
 		panic(err)
 	}
+
+	saveEvent(txWrapper.tx, vote.ProposalId, EVENT_VOTE_CAST, vote.Voter.String(), vote.Answer, vote.BlockHeight)
 }
 
 func UpdateVote(vote *Vote) {
@@ -753,6 +590,8 @@ func UpdateVote(vote *Vote) {
 		fmt.Println(err)
 		panic(err)
 	}
+
+	saveEvent(txWrapper.tx, vote.ProposalId, EVENT_VOTE_CHANGED, vote.Voter.String(), vote.Answer, vote.BlockHeight)
 }
 
 func GetVoteByPidAndVoter(pid string, voter string) *Vote {
@@ -787,7 +626,21 @@ func GetVotesByPid(pid string) (votes []*Vote) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
 
-	stmt, err := txWrapper.tx.Prepare("select voter, answer, block_height, hash from governance_vote where proposal_id = ?")
+	return getVotesByPid(txWrapper.tx, pid)
+}
+
+func QueryVotesByPid(pid string) (votes []*Vote) {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	return getVotesByPid(tx, pid)
+}
+
+func getVotesByPid(tx *sql.Tx, pid string) (votes []*Vote) {
+	stmt, err := tx.Prepare("select voter, answer, block_height, hash from governance_vote where proposal_id = ?")
 	if err != nil {
 		panic(err)
 	}
@@ -823,3 +676,276 @@ func GetVotesByPid(pid string) (votes []*Vote) {
 
 	return
 }
+
+func QueryVotesByVoter(voter string) (votes []*Vote) {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	return getVotesByVoter(tx, common.HexToAddress(voter))
+}
+
+func getVotesByVoter(tx *sql.Tx, voter common.Address) (votes []*Vote) {
+	stmt, err := tx.Prepare("select proposal_id, answer, block_height from governance_vote where voter = ? order by block_height desc")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(voter.String())
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pid, answer string
+		var blockHeight int64
+		err = rows.Scan(&pid, &answer, &blockHeight)
+		if err != nil {
+			panic(err)
+		}
+
+		votes = append(votes, &Vote{
+			pid,
+			voter,
+			blockHeight,
+			answer,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return
+}
+
+// saveValidatorSnapshot records the validators with the delegations of their votes when the proposal is created,
+// the delegations changed afterwards don't apply to the proposal
+func saveValidatorSnapshot(tx *sql.Tx, pid string, validators stake.Validators) {
+	delegatees := make(map[string]string)
+	for _, d := range getVoteDelegations(tx) {
+		delegatees[d.Delegator.String()] = d.Delegatee.String()
+	}
+
+	stmt, err := tx.Prepare("insert into governance_validator_snapshot(proposal_id, owner_address, voting_power, delegatee, hash) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	for _, v := range validators {
+		sv := &SnapshotValidator{pid, v.OwnerAddress, v.VotingPower, delegatees[v.OwnerAddress]}
+		_, err = stmt.Exec(sv.ProposalId, sv.OwnerAddress, sv.VotingPower, sv.Delegatee, common.Bytes2Hex(sv.Hash()))
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+}
+
+func GetValidatorSnapshot(pid string) []*SnapshotValidator {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	return getValidatorSnapshot(txWrapper.tx, pid)
+}
+
+func QueryValidatorSnapshot(pid string) []*SnapshotValidator {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	return getValidatorSnapshot(tx, pid)
+}
+
+func getValidatorSnapshot(tx *sql.Tx, pid string) (validators []*SnapshotValidator) {
+	stmt, err := tx.Prepare("select owner_address, voting_power, delegatee from governance_validator_snapshot where proposal_id = ? order by voting_power desc, owner_address")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(pid)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ownerAddress, delegatee string
+		var votingPower int64
+		err = rows.Scan(&ownerAddress, &votingPower, &delegatee)
+		if err != nil {
+			panic(err)
+		}
+
+		validators = append(validators, &SnapshotValidator{
+			pid,
+			ownerAddress,
+			votingPower,
+			delegatee,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return
+}
+
+// SaveVoteDelegation sets the delegatee of the delegator, replacing the previous one if any
+func SaveVoteDelegation(delegation *VoteDelegation) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("replace into governance_vote_delegation(delegator, delegatee, block_height, hash) values(?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(delegation.Delegator.String(), delegation.Delegatee.String(), delegation.BlockHeight, common.Bytes2Hex(delegation.Hash()))
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+func DeleteVoteDelegation(delegator common.Address) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("delete from governance_vote_delegation where delegator = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(delegator.String())
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+func GetVoteDelegation(delegator common.Address) *VoteDelegation {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("select delegatee, block_height from governance_vote_delegation where delegator = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	var delegatee string
+	var blockHeight int64
+	err = stmt.QueryRow(delegator.String()).Scan(&delegatee, &blockHeight)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		panic(err)
+	}
+
+	return &VoteDelegation{
+		delegator,
+		common.HexToAddress(delegatee),
+		blockHeight,
+	}
+}
+
+func GetVoteDelegations() []*VoteDelegation {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	return getVoteDelegations(txWrapper.tx)
+}
+
+func QueryVoteDelegations() []*VoteDelegation {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	return getVoteDelegations(tx)
+}
+
+func getVoteDelegations(tx *sql.Tx) (delegations []*VoteDelegation) {
+	rows, err := tx.Query("select delegator, delegatee, block_height from governance_vote_delegation")
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var delegator, delegatee string
+		var blockHeight int64
+		err = rows.Scan(&delegator, &delegatee, &blockHeight)
+		if err != nil {
+			panic(err)
+		}
+
+		delegations = append(delegations, &VoteDelegation{
+			common.HexToAddress(delegator),
+			common.HexToAddress(delegatee),
+			blockHeight,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return
+}
+
+func SaveTally(tally *Tally) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("replace into governance_tally(proposal_id, yes_power, no_power, abstain_power, veto_power, total_power, block_height) values(?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(tally.ProposalId, tally.YesPower, tally.NoPower, tally.AbstainPower, tally.VetoPower, tally.TotalPower, tally.BlockHeight)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+func QueryTallyByPid(pid string) *Tally {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	stmt, err := tx.Prepare("select yes_power, no_power, abstain_power, veto_power, total_power, block_height from governance_tally where proposal_id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	var yesPower, noPower, abstainPower, vetoPower, totalPower, blockHeight int64
+	err = stmt.QueryRow(pid).Scan(&yesPower, &noPower, &abstainPower, &vetoPower, &totalPower, &blockHeight)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		panic(err)
+	}
+
+	return NewTally(pid, yesPower, noPower, abstainPower, vetoPower, totalPower, blockHeight)
+}
@@ -0,0 +1,47 @@
+package governance
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vangjvn/devchain/sdk/dbm"
+)
+
+// testSchema is the part of the devchain database used by the tests of the module
+const testSchema = `
+	create table candidates(id integer not null primary key autoincrement, address text not null, pub_key text not null, voting_power integer default 0, name text not null default '', website text not null default '', location text not null default '', email text not null default '', profile text not null default '', verified text not null default 'N', active text not null default 'Y', admitted text not null default 'N', state text not null default '', hash text not null default '', block_height integer not null, created_at integer not null);
+	create table governance_proposal(id text not null primary key, type text not null, proposer text not null, block_height integer not null, expire_timestamp integer not null, expire_block_height integer not null, hash text not null default '', result text not null default '', result_msg text not null default '', result_block_height integer not null default 0, deposit text not null default '0', execution_delay integer not null default 0, activation_block_height integer not null default 0, execute_block_height integer not null default 0);
+	create table governance_transfer_fund_detail(proposal_id text not null, from_address text not null, to_address text not null, amount text not null, reason text not null);
+	create table governance_change_param_detail(proposal_id text not null, param_name text not null, param_value text not null, reason text not null, params text not null default '');
+	create table governance_deploy_libeni_detail(proposal_id text not null, name text not null, version text not null, fileurl text not null, md5 text not null, sha256 text not null default '', signature text not null default '', reason text not null, status text not null);
+	create table governance_text_detail(proposal_id text not null, title text not null, description text not null, document_url text not null, document_hash text not null);
+	create table governance_vote(proposal_id text not null, voter text not null, block_height integer not null, answer text not null,  hash text not null default '', unique(proposal_id, voter) ON conflict replace);
+	create table governance_tally(proposal_id text not null primary key, yes_power integer not null, no_power integer not null, abstain_power integer not null, veto_power integer not null, total_power integer not null, block_height integer not null);
+	create table governance_validator_snapshot(proposal_id text not null, owner_address text not null, voting_power integer not null, delegatee text not null default '', hash text not null default '', unique(proposal_id, owner_address));
+	create table governance_vote_delegation(delegator text not null primary key, delegatee text not null, block_height integer not null, hash text not null default '');
+	create table governance_event(id integer primary key autoincrement, proposal_id text not null, type text not null, actor text not null default '', detail text not null default '', block_height integer not null, prev_hash text not null default '', hash text not null);
+	create trigger governance_event_no_update before update on governance_event begin select raise(abort, 'governance_event is append-only'); end;
+	create trigger governance_event_no_delete before delete on governance_event begin select raise(abort, 'governance_event is append-only'); end;
+`
+
+// setupTestDb creates the test schema in a temporary devchain database,
+// the returned func closes and removes the database
+func setupTestDb(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "governance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbm.InitSqliter(filepath.Join(dir, "devchain.db")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = getDb().Exec(testSchema); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		dbm.Sqliter.CloseDB()
+		os.RemoveAll(dir)
+	}
+}
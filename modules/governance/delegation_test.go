@@ -0,0 +1,69 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/vangjvn/devchain/modules/stake"
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+)
+
+// saveValidator stores an active candidate with voting power, owned by the address
+func saveValidator(owner common.Address, votingPower int64) {
+	stake.SaveCandidate(&stake.Candidate{
+		PubKey:       types.PubKey{PubKey: ed25519.GenPrivKey().PubKey()},
+		OwnerAddress: owner.String(),
+		VotingPower:  votingPower,
+		Verified:     "N",
+		Active:       "Y",
+		Admitted:     "N",
+		State:        "Validator",
+	})
+}
+
+func TestVoteDelegationTxs(t *testing.T) {
+	defer setupTestDb(t)()
+
+	alice := common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+	bob := common.HexToAddress("0x77beb894fc9b0ed41231e51f128a347043960a9d")
+	carol := common.HexToAddress("0x84f444c0405c761f5a2b6ac6ee6fb4ebc7a8c4b3")
+	outsider := common.HexToAddress("0x283ed77f880d87dbdef2c2bbed8e7a2ddb9d1e80")
+	saveValidator(alice, 1000)
+	saveValidator(bob, 1000)
+	saveValidator(carol, 1000)
+
+	deliver := func(sender common.Address, height int64, tx sdk.Tx) error {
+		ctx := types.NewContext("test", height, 0, nil)
+		ctx.WithSigners(sender)
+		_, err := DeliverTx(ctx, nil, tx, nil)
+		return err
+	}
+
+	t.Run("rejected", func(t *testing.T) {
+		require.Equal(t, ErrInvalidValidator().Error(), deliver(outsider, 1, NewTxDelegateVote(&bob)).Error())
+		require.Equal(t, ErrInvalidDelegatee().Error(), deliver(alice, 1, NewTxDelegateVote(&alice)).Error())
+		require.Equal(t, ErrInvalidDelegatee().Error(), deliver(alice, 1, NewTxDelegateVote(&outsider)).Error())
+		require.Equal(t, ErrNoVoteDelegation().Error(), deliver(alice, 1, NewTxRevokeVoteDelegation()).Error())
+		require.Empty(t, GetVoteDelegations())
+	})
+
+	t.Run("delegated", func(t *testing.T) {
+		require.NoError(t, deliver(alice, 2, NewTxDelegateVote(&bob)))
+		require.Equal(t, &VoteDelegation{alice, bob, 2}, GetVoteDelegation(alice))
+	})
+
+	t.Run("delegated again", func(t *testing.T) {
+		require.NoError(t, deliver(alice, 3, NewTxDelegateVote(&carol)))
+		require.Equal(t, &VoteDelegation{alice, carol, 3}, GetVoteDelegation(alice))
+		require.Len(t, GetVoteDelegations(), 1)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		require.NoError(t, deliver(alice, 4, NewTxRevokeVoteDelegation()))
+		require.Nil(t, GetVoteDelegation(alice))
+	})
+}
@@ -0,0 +1,45 @@
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vangjvn/devchain/utils"
+)
+
+func TestSettleDeposit(t *testing.T) {
+	proposer := common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+
+	settle := func(deposit, checkResult string) (proposerBalance, burnt, held *big.Int) {
+		state := newTestState(t)
+		state.AddBalance(utils.GovHoldAccount, big.NewInt(500))
+		SettleDeposit(state, &Proposal{Proposer: &proposer, Deposit: deposit}, checkResult)
+		return state.GetBalance(proposer), state.GetBalance(utils.MintAccount), state.GetBalance(utils.GovHoldAccount)
+	}
+
+	t.Run("burnt on rejection", func(t *testing.T) {
+		proposerBalance, burnt, held := settle("500", "rejected")
+		assert.Equal(t, int64(0), proposerBalance.Int64())
+		assert.Equal(t, int64(500), burnt.Int64())
+		assert.Equal(t, int64(0), held.Int64())
+	})
+
+	for _, checkResult := range []string{"approved", "not determined", "cancelled"} {
+		t.Run("refunded when "+checkResult, func(t *testing.T) {
+			proposerBalance, burnt, held := settle("500", checkResult)
+			assert.Equal(t, int64(500), proposerBalance.Int64())
+			assert.Equal(t, int64(0), burnt.Int64())
+			assert.Equal(t, int64(0), held.Int64())
+		})
+	}
+
+	t.Run("no deposit", func(t *testing.T) {
+		proposerBalance, burnt, held := settle("", "rejected")
+		assert.Equal(t, int64(0), proposerBalance.Int64())
+		assert.Equal(t, int64(0), burnt.Int64())
+		assert.Equal(t, int64(500), held.Int64())
+	})
+}
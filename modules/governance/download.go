@@ -0,0 +1,334 @@
+package governance
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/vm/eni"
+
+	"github.com/vangjvn/devchain/types"
+)
+
+const (
+	DOWNLOAD_STATUS_DOWNLOADING = "downloading"
+	DOWNLOAD_STATUS_WAITING     = "waiting"
+	DOWNLOAD_STATUS_DONE        = "done"
+	DOWNLOAD_STATUS_FAILED      = "failed"
+	DOWNLOAD_STATUS_CANCELLED   = "cancelled"
+)
+
+// DownloadConfig is the retry policy of the library downloads, set from the [download] section of the node config
+type DownloadConfig struct {
+	// seconds to wait after the first failed attempt, doubled after every further failure up to MaxBackoff
+	MinBackoff int `mapstructure:"min_backoff"`
+	MaxBackoff int `mapstructure:"max_backoff"`
+	// the download fails after this number of attempts, 0 retries until the proposal is resolved
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// file:// or http urls of local mirrors laid out as the artifact cache, searched before the urls of the release
+	Mirrors []string `mapstructure:"mirrors"`
+}
+
+func DefaultDownloadConfig() DownloadConfig {
+	return DownloadConfig{
+		MinBackoff:  10,
+		MaxBackoff:  600,
+		MaxAttempts: 0,
+	}
+}
+
+// DownloadProgress is the progress of the download of the library of a deploy_libeni proposal on this node,
+// it is kept in memory only, the outcome is recorded as the status of the proposal
+type DownloadProgress struct {
+	ProposalId    string `json:"proposal_id"`
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	MaxAttempts   int    `json:"max_attempts"`
+	LastError     string `json:"last_error"`
+	StartedAt     int64  `json:"started_at"`
+	UpdatedAt     int64  `json:"updated_at"`
+	NextAttemptAt int64  `json:"next_attempt_at"`
+	// status of the proposal, i.e. ready, deployed, failed or collapsed, empty while downloading
+	LibEniStatus string `json:"libeni_status"`
+}
+
+type download struct {
+	progress DownloadProgress
+	checksum *types.ArtifactChecksum
+	// publisher keys at the submission, replaced by the ones at the approval for the registration,
+	// as the params can't be read from the download goroutine
+	publisherKeys []string
+	cancel        context.CancelFunc
+	// set when the download is cancelled by the approval of the proposal
+	approved bool
+}
+
+// downloadManager runs one download per deploy_libeni proposal,
+// the download is retried with a backoff until it succeeds, runs out of attempts or is cancelled
+type downloadManager struct {
+	mtx       sync.Mutex
+	config    DownloadConfig
+	cache     *types.ArtifactCache
+	downloads map[string]*download
+}
+
+var downloads = &downloadManager{
+	config:    DefaultDownloadConfig(),
+	cache:     types.NewArtifactCache(filepath.Join(os.TempDir(), "artifacts"), nil),
+	downloads: make(map[string]*download),
+}
+
+// SetDownloadConfig sets the retry policy of the downloads started afterwards
+func SetDownloadConfig(config DownloadConfig) {
+	if config.MinBackoff < 1 {
+		config.MinBackoff = 1
+	}
+	if config.MaxBackoff < config.MinBackoff {
+		config.MaxBackoff = config.MinBackoff
+	}
+	if config.MaxAttempts < 0 {
+		config.MaxAttempts = 0
+	}
+
+	downloads.mtx.Lock()
+	defer downloads.mtx.Unlock()
+	downloads.config = config
+}
+
+// SetArtifactCache sets the cache the libraries are downloaded through, i.e. the one under the node home
+func SetArtifactCache(cache *types.ArtifactCache) {
+	downloads.mtx.Lock()
+	defer downloads.mtx.Unlock()
+	downloads.cache = cache
+}
+
+// start starts the download of the library of the proposal, it does nothing if the download is running
+func (m *downloadManager) start(pid string, oi eni.OTAInfo, checksum *types.ArtifactChecksum, publisherKeys []string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if d, ok := m.downloads[pid]; ok && d.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	now := time.Now().Unix()
+	d := &download{
+		progress: DownloadProgress{
+			ProposalId:  pid,
+			Name:        oi.LibName,
+			Version:     oi.Version,
+			Status:      DOWNLOAD_STATUS_DOWNLOADING,
+			MaxAttempts: m.config.MaxAttempts,
+			StartedAt:   now,
+			UpdatedAt:   now,
+		},
+		checksum:      checksum,
+		publisherKeys: publisherKeys,
+		cancel:        cancel,
+	}
+	m.downloads[pid] = d
+
+	go m.run(ctx, d, oi, m.config, m.cache)
+}
+
+// run retries the download until it ends. The cancellation interrupts the fetch of an attempt in progress,
+// or the wait for the next attempt.
+func (m *downloadManager) run(ctx context.Context, d *download, oi eni.OTAInfo, config DownloadConfig, cache *types.ArtifactCache) {
+	backoff := time.Duration(config.MinBackoff) * time.Second
+	maxBackoff := time.Duration(config.MaxBackoff) * time.Second
+
+	for {
+		attempts := m.update(d, func(p *DownloadProgress) {
+			p.Status = DOWNLOAD_STATUS_DOWNLOADING
+			p.Attempts++
+			p.NextAttemptAt = 0
+		})
+
+		err := attemptDownload(ctx, cache, oi, d.checksum, d.publisherKeys)
+		if err == nil {
+			m.finish(d, oi, nil)
+			return
+		}
+		if ctx.Err() != nil || (config.MaxAttempts > 0 && attempts >= config.MaxAttempts) {
+			m.finish(d, oi, err)
+			return
+		}
+
+		m.update(d, func(p *DownloadProgress) {
+			p.Status = DOWNLOAD_STATUS_WAITING
+			p.LastError = err.Error()
+			p.NextAttemptAt = time.Now().Add(backoff).Unix()
+		})
+
+		select {
+		case <-ctx.Done():
+			m.finish(d, oi, err)
+			return
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// update changes the progress under the lock and returns the number of attempts
+func (m *downloadManager) update(d *download, f func(p *DownloadProgress)) int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	f(&d.progress)
+	d.progress.UpdatedAt = time.Now().Unix()
+	return d.progress.Attempts
+}
+
+// finish records the outcome of the download, err is the error of the last attempt or nil on success.
+// The library is registered if the proposal has been approved while downloading, which is reported as collapsed on failure.
+func (m *downloadManager) finish(d *download, oi eni.OTAInfo, err error) {
+	m.mtx.Lock()
+	cancelled := d.cancel == nil
+	approved := d.approved
+	publisherKeys := d.publisherKeys
+	if !cancelled {
+		d.cancel()
+		d.cancel = nil
+	}
+	switch {
+	case err == nil:
+		d.progress.Status = DOWNLOAD_STATUS_DONE
+	case cancelled:
+		d.progress.Status = DOWNLOAD_STATUS_CANCELLED
+		d.progress.LastError = err.Error()
+	default:
+		d.progress.Status = DOWNLOAD_STATUS_FAILED
+		d.progress.LastError = err.Error()
+	}
+	d.progress.NextAttemptAt = 0
+	d.progress.UpdatedAt = time.Now().Unix()
+	pid := d.progress.ProposalId
+	m.mtx.Unlock()
+
+	if err == nil && approved {
+		if err = registerLibEni(oi, d.checksum, publisherKeys); err == nil {
+			UpdateDeployLibEniStatus(pid, "deployed")
+			return
+		}
+		m.update(d, func(p *DownloadProgress) { p.LastError = err.Error() })
+	}
+
+	switch {
+	case err == nil:
+		UpdateDeployLibEniStatus(pid, "ready")
+	case approved:
+		UpdateDeployLibEniStatus(pid, "collapsed") // failed, but proposal has been approved
+	default:
+		UpdateDeployLibEniStatus(pid, "failed")
+	}
+}
+
+// setError records the error which left the library of the proposal undeployed on this node
+func (m *downloadManager) setError(pid string, err error) {
+	m.mtx.Lock()
+	d, ok := m.downloads[pid]
+	m.mtx.Unlock()
+	if ok {
+		m.update(d, func(p *DownloadProgress) { p.LastError = err.Error() })
+	}
+}
+
+// attemptDownload fetches the verified artifact into the cache, then has eni download the library
+// from the cached artifact first, and from the urls of the release if it doesn't support file:// urls.
+// Eni checks its download against the md5 of the verified artifact.
+func attemptDownload(ctx context.Context, cache *types.ArtifactCache, oi eni.OTAInfo, checksum *types.ArtifactChecksum, publisherKeys []string) error {
+	path, err := cache.Fetch(ctx, oi.Url, checksum, publisherKeys)
+	if err != nil {
+		return err
+	}
+	if oi.Checksum, err = types.FileMd5(path); err != nil {
+		return err
+	}
+	oi.Url = append([]string{"file://" + path}, oi.Url...)
+	return OTAInstance.DownloadInfo(oi)
+}
+
+// cancel stops the download of the proposal once it is resolved, approved tells whether it has been approved,
+// in which case the library is registered against publisherKeys once downloaded
+func (m *downloadManager) cancel(pid string, approved bool, publisherKeys []string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	d, ok := m.downloads[pid]
+	if !ok || d.cancel == nil {
+		return
+	}
+	d.approved = approved
+	d.publisherKeys = publisherKeys
+	d.cancel()
+	d.cancel = nil
+}
+
+func (m *downloadManager) get(pid string) *DownloadProgress {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	d, ok := m.downloads[pid]
+	if !ok {
+		return nil
+	}
+	progress := d.progress
+	return &progress
+}
+
+func (m *downloadManager) list() []*DownloadProgress {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	list := make([]*DownloadProgress, 0, len(m.downloads))
+	for _, d := range m.downloads {
+		progress := d.progress
+		list = append(list, &progress)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].StartedAt > list[j].StartedAt })
+	return list
+}
+
+// QueryDownload returns the download progress of a deploy_libeni proposal on this node,
+// or nil if it is not a deploy_libeni proposal
+func QueryDownload(pid string) *DownloadProgress {
+	p := QueryProposalById(pid)
+	if p == nil {
+		return nil
+	}
+	c, ok := p.Content.(*DeployLibEniContent)
+	if !ok {
+		return nil
+	}
+
+	progress := downloads.get(pid)
+	if progress == nil {
+		// not downloaded since the node started
+		progress = &DownloadProgress{ProposalId: pid, Name: c.Name, Version: c.Version}
+	}
+	progress.LibEniStatus = c.Status
+	return progress
+}
+
+// QueryDownloads returns the downloads run since the node started, the latest first
+func QueryDownloads() []*DownloadProgress {
+	list := downloads.list()
+	for _, progress := range list {
+		if p := QueryProposalById(progress.ProposalId); p != nil {
+			if c, ok := p.Content.(*DeployLibEniContent); ok {
+				progress.LibEniStatus = c.Status
+			}
+		}
+	}
+	return list
+}
@@ -0,0 +1,108 @@
+package governance
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/vm/eni"
+
+	"github.com/vangjvn/devchain/types"
+)
+
+// newTestDownloads returns a download manager with an empty cache, and the release of a library which can't be downloaded
+func newTestDownloads(t *testing.T, config DownloadConfig) (*downloadManager, eni.OTAInfo, func()) {
+	dir, err := ioutil.TempDir("", "artifacts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &downloadManager{
+		config:    config,
+		cache:     types.NewArtifactCache(dir, nil),
+		downloads: make(map[string]*download),
+	}
+	oi := eni.OTAInfo{LibName: "reverse", Version: "v1.0.0", Url: []string{"file://" + dir + "/missing/reverse.so"}}
+	return m, oi, func() { os.RemoveAll(dir) }
+}
+
+func saveLibEniDetail(t *testing.T, pid string) {
+	_, err := getDb().Exec("insert into governance_deploy_libeni_detail(proposal_id, name, version, fileurl, md5, reason, status) values(?, 'reverse', 'v1.0.0', '', '', '', '')", pid)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitFor polls the condition until it holds, or fails the test after 10 seconds
+func waitFor(t *testing.T, what string, cond func() bool) {
+	for deadline := time.Now().Add(10 * time.Second); !cond(); time.Sleep(50 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+	}
+}
+
+func libEniStatus(pid string) string {
+	var status string
+	getDb().QueryRow("select status from governance_deploy_libeni_detail where proposal_id = ?", pid).Scan(&status)
+	return status
+}
+
+func TestDownloadRetries(t *testing.T) {
+	defer setupTestDb(t)()
+	saveLibEniDetail(t, "p1")
+
+	m, oi, cleanup := newTestDownloads(t, DownloadConfig{MinBackoff: 1, MaxBackoff: 1, MaxAttempts: 2})
+	defer cleanup()
+
+	checksum := &types.ArtifactChecksum{MD5: "d41d8cd98f00b204e9800998ecf8427e"}
+	m.start("p1", oi, checksum, nil)
+	// a download in progress isn't started again
+	m.start("p1", oi, checksum, nil)
+
+	waitFor(t, "the download to fail", func() bool { return m.get("p1").Status == DOWNLOAD_STATUS_FAILED })
+	progress := m.get("p1")
+	if progress.Attempts != 2 || progress.MaxAttempts != 2 {
+		t.Errorf("attempts %d of %d, want 2 of 2", progress.Attempts, progress.MaxAttempts)
+	}
+	if progress.LastError == "" {
+		t.Error("the error of the last attempt should be reported")
+	}
+	waitFor(t, "the failed status", func() bool { return libEniStatus("p1") == "failed" })
+
+	if list := m.list(); len(list) != 1 || list[0].ProposalId != "p1" {
+		t.Errorf("listed %v, want the download of p1", list)
+	}
+	if m.get("p2") != nil {
+		t.Error("no download for p2")
+	}
+}
+
+func TestDownloadCancel(t *testing.T) {
+	defer setupTestDb(t)()
+	saveLibEniDetail(t, "p1")
+
+	m, oi, cleanup := newTestDownloads(t, DownloadConfig{MinBackoff: 60, MaxBackoff: 60})
+	defer cleanup()
+
+	m.start("p1", oi, &types.ArtifactChecksum{MD5: "d41d8cd98f00b204e9800998ecf8427e"}, nil)
+	waitFor(t, "the first attempt to fail", func() bool { return m.get("p1").Status == DOWNLOAD_STATUS_WAITING })
+
+	// the cancellation interrupts the wait for the next attempt
+	m.cancel("p1", false, nil)
+	waitFor(t, "the cancellation", func() bool { return m.get("p1").Status == DOWNLOAD_STATUS_CANCELLED })
+	if attempts := m.get("p1").Attempts; attempts != 1 {
+		t.Errorf("attempts %d, want 1", attempts)
+	}
+	waitFor(t, "the failed status", func() bool { return libEniStatus("p1") == "failed" })
+}
+
+func TestSetDownloadConfig(t *testing.T) {
+	saved := downloads.config
+	defer func() { downloads.config = saved }()
+
+	SetDownloadConfig(DownloadConfig{MinBackoff: 0, MaxBackoff: -5, MaxAttempts: -1})
+	if c := downloads.config; c.MinBackoff != 1 || c.MaxBackoff != 1 || c.MaxAttempts != 0 {
+		t.Errorf("got %+v, want the backoffs raised to 1s and unlimited attempts", c)
+	}
+}
@@ -27,6 +27,25 @@ var (
 	errOngoingLibFound          = fmt.Errorf("One or more onging proposal with the same lib name")
 	errOngoingRetiringFound     = fmt.Errorf("Found unresolved or approved retiring proposal")
 	errExpirationTooClose       = fmt.Errorf("The proposal's expiration block height is too close")
+	errNotProposer              = fmt.Errorf("Only the proposer can cancel the proposal")
+	errCancelledProposal        = fmt.Errorf("The proposal has been cancelled")
+	errInvalidTimelock          = fmt.Errorf("Invalid execution delay or activation block height")
+	errInvalidDelegatee         = fmt.Errorf("The delegatee must be another validator")
+	errNoVoteDelegation         = fmt.Errorf("No vote delegation found")
+	errCandidateNotFound        = fmt.Errorf("The candidate is not found")
+	errCandidateAdmitted        = fmt.Errorf("The candidate has already been admitted")
+	errLastValidator            = fmt.Errorf("The last validator can't be removed")
+	errInvalidGrantSchedule     = fmt.Errorf("The grant must be paid out in at least one tranche, at an interval of at least one block")
+	errGrantNotFound            = fmt.Errorf("The grant can't be found")
+	errInactiveGrant            = fmt.Errorf("The grant has already been completed or cancelled")
+	errInvalidCallGas           = fmt.Errorf("The gas of the contract call must be positive and no more than the contract_call_proposal_max_gas param")
+	errInvalidPauseDuration     = fmt.Errorf("The pause must last at least one block and no more than the emergency_pause_duration param")
+	errPaused                   = fmt.Errorf("EVM transactions are paused by an emergency pause proposal")
+	errNotPaused                = fmt.Errorf("There is no active emergency pause")
+	errInvalidSha256Json        = fmt.Errorf("The sha256 is not a valid json")
+	errNoSha256                 = fmt.Errorf("Can not find sha256 for current os, md5 is only accepted for legacy releases")
+	errInvalidReleaseSignature  = fmt.Errorf("The sha256 of the release is not signed by any of the ota_publisher_keys")
+	errInvalidVoteAnswer        = fmt.Errorf("Invalid answer, should be one of Y, N, A(abstain) and V(no with veto)")
 )
 
 func ErrMissingSignature() error {
@@ -104,3 +123,84 @@ func ErrOngoingRetiringFound() error {
 func ErrExpirationTooClose() error {
 	return errors.WithCode(errExpirationTooClose, errors.CodeTypeBaseInvalidInput)
 }
+
+func ErrInvalidVoteAnswer() error {
+	return errors.WithCode(errInvalidVoteAnswer, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrNotProposer() error {
+	return errors.WithCode(errNotProposer, errors.CodeTypeUnauthorized)
+}
+
+func ErrCancelledProposal() error {
+	return errors.WithCode(errCancelledProposal, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInvalidTimelock() error {
+	return errors.WithCode(errInvalidTimelock, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInvalidDelegatee() error {
+	return errors.WithCode(errInvalidDelegatee, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrNoVoteDelegation() error {
+	return errors.WithCode(errNoVoteDelegation, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrCandidateNotFound() error {
+	return errors.WithCode(errCandidateNotFound, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrCandidateAdmitted() error {
+	return errors.WithCode(errCandidateAdmitted, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrLastValidator() error {
+	return errors.WithCode(errLastValidator, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInvalidGrantSchedule() error {
+	return errors.WithCode(errInvalidGrantSchedule, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrGrantNotFound() error {
+	return errors.WithCode(errGrantNotFound, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInactiveGrant() error {
+	return errors.WithCode(errInactiveGrant, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInvalidCallGas() error {
+	return errors.WithCode(errInvalidCallGas, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInvalidPauseDuration() error {
+	return errors.WithCode(errInvalidPauseDuration, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrPaused() error {
+	return errors.WithCode(errPaused, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrNotPaused() error {
+	return errors.WithCode(errNotPaused, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInvalidSha256Json() error {
+	return errors.WithCode(errInvalidSha256Json, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrNoSha256() error {
+	return errors.WithCode(errNoSha256, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInvalidReleaseSignature() error {
+	return errors.WithCode(errInvalidReleaseSignature, errors.CodeTypeBaseInvalidInput)
+}
+
+// ErrInconsistentParams is returned with the error of the params validation
+func ErrInconsistentParams(err error) error {
+	return errors.WithCode(err, errors.CodeTypeBaseInvalidInput)
+}
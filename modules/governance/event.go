@@ -0,0 +1,91 @@
+package governance
+
+import (
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// workingHeight is the height of the block being processed, it is used for the events
+// which are not triggered by a tx, e.g. the completion of a LibEni download
+var workingHeight int64
+
+func setWorkingHeight(height int64) {
+	atomic.StoreInt64(&workingHeight, height)
+}
+
+func getWorkingHeight() int64 {
+	return atomic.LoadInt64(&workingHeight)
+}
+
+// saveEvent appends an event to the governance event log within the given sql tx,
+// so that the event is recorded together with the state transition it describes
+func saveEvent(tx *sql.Tx, pid, etype, actor, detail string, blockHeight int64) {
+	var prevHash string
+	err := tx.QueryRow("select hash from governance_event order by id desc limit 1").Scan(&prevHash)
+	if err != nil && err != sql.ErrNoRows {
+		panic(err)
+	}
+
+	event := &Event{
+		0,
+		pid,
+		etype,
+		actor,
+		detail,
+		blockHeight,
+		prevHash,
+		"",
+	}
+	event.Hash = common.Bytes2Hex(event.ComputeHash())
+
+	stmt, err := tx.Prepare("insert into governance_event(proposal_id, type, actor, detail, block_height, prev_hash, hash) values(?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(event.ProposalId, event.Type, event.Actor, event.Detail, event.BlockHeight, event.PrevHash, event.Hash)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+// QueryEventsByPid returns the events of a proposal in the order they were recorded
+func QueryEventsByPid(pid string) (events []*Event) {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	stmt, err := tx.Prepare("select id, type, actor, detail, block_height, prev_hash, hash from governance_event where proposal_id = ? order by id")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(pid)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		event := &Event{ProposalId: pid}
+		err = rows.Scan(&event.Id, &event.Type, &event.Actor, &event.Detail, &event.BlockHeight, &event.PrevHash, &event.Hash)
+		if err != nil {
+			panic(err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return
+}
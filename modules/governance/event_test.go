@@ -0,0 +1,74 @@
+package governance
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLog(t *testing.T) {
+	defer setupTestDb(t)()
+
+	alice := common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+	bob := common.HexToAddress("0x77beb894fc9b0ed41231e51f128a347043960a9d")
+	newProposal := func(pid string, height int64) {
+		SaveProposal(&Proposal{Id: pid, Type: TEXT_PROPOSAL, Proposer: &alice, BlockHeight: height,
+			ExpireBlockHeight: 1000, Deposit: "0", Content: &TextContent{Title: pid}})
+	}
+
+	newProposal("p1", 1)
+	SaveVote(NewVote("p1", bob, 2, VOTE_NO))
+	newProposal("p2", 3)
+	UpdateVote(NewVote("p1", bob, 4, VOTE_YES))
+	UpdateProposalResult("p1", "Approved", "", 4)
+
+	events := QueryEventsByPid("p1")
+	require.Len(t, events, 4)
+
+	expected := []struct {
+		etype, actor, detail string
+		height               int64
+	}{
+		{EVENT_PROPOSAL_CREATED, alice.String(), TEXT_PROPOSAL, 1},
+		{EVENT_VOTE_CAST, bob.String(), VOTE_NO, 2},
+		{EVENT_VOTE_CHANGED, bob.String(), VOTE_YES, 4},
+		{"approved", "", "", 4},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.etype, events[i].Type)
+		assert.Equal(t, e.actor, events[i].Actor, e.etype)
+		assert.Equal(t, e.detail, events[i].Detail, e.etype)
+		assert.Equal(t, e.height, events[i].BlockHeight, e.etype)
+	}
+
+	// the events of all proposals are chained together in the order they are recorded
+	all := append(QueryEventsByPid("p1"), QueryEventsByPid("p2")...)
+	sort.Slice(all, func(i, j int) bool { return all[i].Id < all[j].Id })
+	prevHash := ""
+	for _, e := range all {
+		assert.Equal(t, prevHash, e.PrevHash, "event %d", e.Id)
+		assert.Equal(t, common.Bytes2Hex(e.ComputeHash()), e.Hash, "event %d", e.Id)
+		prevHash = e.Hash
+	}
+
+	// altering the detail of an event breaks its hash
+	tampered := *events[1]
+	tampered.Detail = VOTE_YES
+	assert.NotEqual(t, tampered.Hash, common.Bytes2Hex(tampered.ComputeHash()))
+}
+
+func TestEventLogAppendOnly(t *testing.T) {
+	defer setupTestDb(t)()
+
+	alice := common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+	SaveVote(NewVote("p1", alice, 1, VOTE_YES))
+
+	_, err := getDb().Exec("update governance_event set detail = 'N'")
+	assert.Error(t, err)
+	_, err = getDb().Exec("delete from governance_event")
+	assert.Error(t, err)
+	assert.Len(t, QueryEventsByPid("p1"), 1)
+}
@@ -0,0 +1,176 @@
+package governance
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethState "github.com/ethereum/go-ethereum/core/state"
+
+	"github.com/vangjvn/devchain/utils"
+)
+
+func saveGrant(tx *sql.Tx, g *Grant) {
+	stmt, err := tx.Prepare("insert into governance_grant(proposal_id, from_address, to_address, amount, paid, tranches, tranches_paid, tranche_interval, next_block_height, status, block_height, hash) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(g.ProposalId, g.From.String(), g.To.String(), g.Amount, g.Paid, g.Tranches, g.TranchesPaid, g.Interval, g.NextBlockHeight, g.Status, g.BlockHeight, common.Bytes2Hex(g.Hash()))
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+func updateGrant(tx *sql.Tx, g *Grant) {
+	stmt, err := tx.Prepare("update governance_grant set paid = ?, tranches_paid = ?, next_block_height = ?, status = ?, block_height = ?, hash = ? where proposal_id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(g.Paid, g.TranchesPaid, g.NextBlockHeight, g.Status, g.BlockHeight, common.Bytes2Hex(g.Hash()), g.ProposalId)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+// getGrants loads the grants, clause is appended to the query, e.g. a where clause
+func getGrants(tx *sql.Tx, clause string, args ...interface{}) (grants []*Grant) {
+	rows, err := tx.Query("select proposal_id, from_address, to_address, amount, paid, tranches, tranches_paid, tranche_interval, next_block_height, status, block_height from governance_grant"+clause, args...)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var from, to string
+		g := &Grant{}
+		err = rows.Scan(&g.ProposalId, &from, &to, &g.Amount, &g.Paid, &g.Tranches, &g.TranchesPaid, &g.Interval, &g.NextBlockHeight, &g.Status, &g.BlockHeight)
+		if err != nil {
+			panic(err)
+		}
+		g.From = common.HexToAddress(from)
+		g.To = common.HexToAddress(to)
+		grants = append(grants, g)
+	}
+
+	if err = rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return
+}
+
+func getGrant(tx *sql.Tx, pid string) *Grant {
+	grants := getGrants(tx, " where proposal_id = ?", pid)
+	if len(grants) == 0 {
+		return nil
+	}
+	return grants[0]
+}
+
+func GetGrant(pid string) *Grant {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	return getGrant(txWrapper.tx, pid)
+}
+
+// QueryGrant returns the payout state of the grant approved by the proposal
+func QueryGrant(pid string) *Grant {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	return getGrant(tx, pid)
+}
+
+// QueryGrants returns the grants with the status, or all of them if the status is empty
+func QueryGrants(status string) []*Grant {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	if status == "" {
+		return getGrants(tx, " order by block_height")
+	}
+	return getGrants(tx, " where status = ? order by block_height", status)
+}
+
+// StartGrant records the payout schedule of an approved grant, the first tranche is released after one interval
+func StartGrant(pid string, c *GrantContent, blockHeight int64) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	saveGrant(txWrapper.tx, &Grant{
+		pid,
+		c.From,
+		c.To,
+		c.Amount,
+		"0",
+		c.Tranches,
+		0,
+		c.Interval,
+		blockHeight + c.Interval,
+		GRANT_STATUS_ACTIVE,
+		blockHeight,
+	})
+}
+
+// ReleaseGrants pays out the tranches of the active grants which are due at the block height,
+// the tranches are moved from the governance hold account to the recipients
+func ReleaseGrants(state *ethState.StateDB, blockHeight int64) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	grants := getGrants(txWrapper.tx, " where status = ? and next_block_height <= ? order by proposal_id", GRANT_STATUS_ACTIVE, blockHeight)
+	for _, g := range grants {
+		tranche := g.nextTranche()
+		state.SubBalance(utils.GovHoldAccount, tranche)
+		state.AddBalance(g.To, tranche)
+
+		paid, _ := new(big.Int).SetString(g.Paid, 10)
+		g.Paid = paid.Add(paid, tranche).String()
+		g.TranchesPaid++
+		g.NextBlockHeight += g.Interval
+		if g.TranchesPaid >= g.Tranches {
+			g.Status = GRANT_STATUS_COMPLETED
+		}
+		g.BlockHeight = blockHeight
+		updateGrant(txWrapper.tx, g)
+
+		saveEvent(txWrapper.tx, g.ProposalId, EVENT_GRANT_PAID, g.To.String(), tranche.String(), blockHeight)
+	}
+}
+
+// ClawBackGrant cancels an active grant, the remaining amount is refunded to the account it was escrowed from.
+// It returns false if the grant is no longer active.
+func ClawBackGrant(state *ethState.StateDB, pid string, blockHeight int64) bool {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	g := getGrant(txWrapper.tx, pid)
+	if g == nil || g.Status != GRANT_STATUS_ACTIVE {
+		return false
+	}
+
+	remaining := g.Remaining()
+	state.SubBalance(utils.GovHoldAccount, remaining)
+	state.AddBalance(g.From, remaining)
+
+	g.Status = GRANT_STATUS_CANCELLED
+	g.BlockHeight = blockHeight
+	updateGrant(txWrapper.tx, g)
+
+	saveEvent(txWrapper.tx, g.ProposalId, EVENT_GRANT_CLAWBACK, g.From.String(), remaining.String(), blockHeight)
+	return true
+}
@@ -1,19 +1,14 @@
 package governance
 
 import (
-	"encoding/json"
 	"errors"
 	"math/big"
-	"strings"
-	"time"
 
-	"github.com/vangjvn/devchain/commons"
 	"github.com/vangjvn/devchain/modules/stake"
 	"github.com/vangjvn/devchain/sdk"
 	"github.com/vangjvn/devchain/sdk/state"
 	"github.com/vangjvn/devchain/types"
 	"github.com/vangjvn/devchain/utils"
-	"github.com/vangjvn/devchain/version"
 	"github.com/ethereum/go-ethereum/common"
 	ethState "github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/vm/eni"
@@ -26,8 +21,6 @@ const governanceModuleName = "governance"
 
 var OTAInstance = eni.NewOTAInstance()
 
-var cancelDownload = make(map[string]bool)
-
 // Name is the name of the modules.
 func Name() string {
 	return governanceModuleName
@@ -50,274 +43,91 @@ func CheckTx(ctx types.Context, store state.SimpleDB,
 	if err != nil {
 		return
 	}
-	app_state := ctx.EthappState()
 
-	switch txInner := tx.Unwrap().(type) {
-	case TxTransferFundPropose:
-		validators := stake.GetCandidates().Validators()
-		if validators == nil || validators.Len() == 0 {
-			return sdk.NewCheck(0, ""), ErrInvalidValidator()
-		}
-		for i, v := range validators {
-			if v.OwnerAddress == sender.String() {
-				break
-			}
-			if i+1 == len(validators) {
-				return sdk.NewCheck(0, ""), ErrInvalidValidator()
-			}
-		}
-
-		amount := big.NewInt(0)
-		amount.SetString(txInner.Amount, 10)
-		if amount.Cmp(big.NewInt(0)) <= 0 {
-			return sdk.NewCheck(0, ""), ErrInvalidParameter()
-		}
-
-		balance, err := commons.GetBalance(app_state, *txInner.From)
-		if err != nil {
-			return sdk.NewCheck(0, ""), ErrInvalidParameter()
-		}
-
-		if balance.Cmp(amount) < 0 {
-			return sdk.NewCheck(0, ""), ErrInsufficientBalance()
-		}
-
-		if txInner.ExpireTimestamp != nil && txInner.ExpireBlockHeight != nil {
-			return sdk.NewCheck(0, ""), ErrExceedsExpiration()
-		}
-
-		if txInner.ExpireTimestamp != nil && ctx.BlockTime() > *txInner.ExpireTimestamp {
-			return sdk.NewCheck(0, ""), ErrInvalidExpireTimestamp()
-		}
-
-		if txInner.ExpireBlockHeight != nil && ctx.BlockHeight() >= *txInner.ExpireBlockHeight {
-			return sdk.NewCheck(0, ""), ErrInvalidExpireBlockHeight()
-		}
-
-		// Transfer gasFee
-		_, err = checkGasFee(app_state, sender, utils.GetParams().TransferFundProposalGas)
-		if err != nil {
+	if ptx, ok := tx.Unwrap().(proposeTx); ok {
+		if err = checkPropose(ctx, sender, ptx.proposal()); err != nil {
 			return sdk.NewCheck(0, ""), err
 		}
-		// app_state.SubBalance(*txInner.From, amount)
-		// app_state.SubBalance(sender, gasFee.Int)
-
-	case TxChangeParamPropose:
-		validators := stake.GetCandidates().Validators()
-		if validators == nil || validators.Len() == 0 {
-			return sdk.NewCheck(0, ""), ErrInvalidValidator()
-		}
-		for i, v := range validators {
-			if v.OwnerAddress == sender.String() {
-				break
-			}
-			if i+1 == len(validators) {
-				return sdk.NewCheck(0, ""), ErrInvalidValidator()
-			}
-		}
-
-		if txInner.ExpireTimestamp != nil && txInner.ExpireBlockHeight != nil {
-			return sdk.NewCheck(0, ""), ErrExceedsExpiration()
-		}
-
-		if txInner.ExpireTimestamp != nil && ctx.BlockTime() > *txInner.ExpireTimestamp {
-			return sdk.NewCheck(0, ""), ErrInvalidExpireTimestamp()
-		}
-
-		if txInner.ExpireBlockHeight != nil && ctx.BlockHeight() >= *txInner.ExpireBlockHeight {
-			return sdk.NewCheck(0, ""), ErrInvalidExpireBlockHeight()
-		}
+		return
+	}
 
-		if !utils.CheckParamType(txInner.Name, txInner.Value) {
+	switch txInner := tx.Unwrap().(type) {
+	case TxVote:
+		proposal := GetProposalById(txInner.ProposalId)
+		if proposal == nil {
 			return sdk.NewCheck(0, ""), ErrInvalidParameter()
 		}
 
-		// Transfer gasFee
-		_, err = checkGasFee(app_state, sender, utils.GetParams().ChangeParamsProposalGas)
-		if err != nil {
-			return sdk.NewCheck(0, ""), err
-		}
-		// app_state.SubBalance(sender, gasFee.Int)
-	case TxDeployLibEniPropose:
-		validators := stake.GetCandidates().Validators()
-		if validators == nil || validators.Len() == 0 {
-			return sdk.NewCheck(0, ""), ErrInvalidValidator()
-		}
-		for i, v := range validators {
+		// only the validators at the creation of the proposal can vote on it
+		inElectorate := false
+		for _, v := range getElectorate(proposal.Id) {
 			if v.OwnerAddress == sender.String() {
+				inElectorate = true
 				break
 			}
-			if i+1 == len(validators) {
-				return sdk.NewCheck(0, ""), ErrInvalidValidator()
-			}
-		}
-
-		if strings.Trim(txInner.Name, " ") == "" || strings.Trim(txInner.Version, " ") == "" {
-			return sdk.NewCheck(0, ""), ErrInsufficientParameters()
 		}
-
-		if txInner.ExpireTimestamp != nil && txInner.ExpireBlockHeight != nil {
-			return sdk.NewCheck(0, ""), ErrExceedsExpiration()
-		}
-
-		if txInner.ExpireTimestamp != nil && ctx.BlockTime() > *txInner.ExpireTimestamp {
-			return sdk.NewCheck(0, ""), ErrInvalidExpireTimestamp()
-		}
-
-		if txInner.ExpireBlockHeight != nil && ctx.BlockHeight() >= *txInner.ExpireBlockHeight {
-			return sdk.NewCheck(0, ""), ErrInvalidExpireBlockHeight()
-		}
-
-		otaInfo := eni.OTAInfo{
-			LibName: txInner.Name,
-			Version: txInner.Version,
-		}
-		if valid, _ := OTAInstance.IsValidNewLib(otaInfo); !valid {
-			return sdk.NewCheck(0, ""), ErrInvalidNewLib()
-		}
-
-		if HasUndeployedProposal(txInner.Name) {
-			return sdk.NewCheck(0, ""), ErrOngoingLibFound()
-		}
-
-		var fileurlJson map[string][]string
-
-		if err = json.Unmarshal([]byte(txInner.FileUrl), &fileurlJson); err != nil {
-			return sdk.NewCheck(0, ""), ErrInvalidFileurlJson()
-		}
-
-		if _, ok := fileurlJson[utils.GOOSDIST]; !ok {
-			return sdk.NewCheck(0, ""), ErrNoFileurl()
-		}
-
-		var md5Json map[string]string
-
-		if err = json.Unmarshal([]byte(txInner.Md5), &md5Json); err != nil {
-			return sdk.NewCheck(0, ""), ErrInvalidMd5Json()
-		}
-
-		if _, ok := md5Json[utils.GOOSDIST]; !ok {
-			return sdk.NewCheck(0, ""), ErrNoMd5()
-		}
-
-		// Transfer gasFee
-		_, err = checkGasFee(app_state, sender, utils.GetParams().DeployLibEniProposalGas)
-		if err != nil {
-			return sdk.NewCheck(0, ""), err
-		}
-		// app_state.SubBalance(sender, gasFee.Int)
-	case TxRetireProgramPropose:
-		validators := stake.GetCandidates().Validators()
-		if validators == nil || validators.Len() == 0 {
+		if !inElectorate {
 			return sdk.NewCheck(0, ""), ErrInvalidValidator()
 		}
-		for i, v := range validators {
-			if v.OwnerAddress == sender.String() {
-				break
-			}
-			if i+1 == len(validators) {
-				return sdk.NewCheck(0, ""), ErrInvalidValidator()
-			}
-		}
-
-		rp := GetRetiringProposal(version.Version)
-		if rp != nil {
-			return sdk.NewCheck(0, ""), ErrOngoingRetiringFound()
-		}
 
-		if txInner.PreservedValidators == "" {
-			return sdk.NewCheck(0, ""), ErrInvalidParameter()
-		}
-
-		if txInner.ExpireBlockHeight != nil && ctx.BlockHeight() >= *txInner.ExpireBlockHeight {
-			return sdk.NewCheck(0, ""), ErrInvalidExpireBlockHeight()
+		if proposal.ExpireBlockHeight > 0 && ctx.BlockHeight() >= proposal.ExpireBlockHeight - 2 {
+			return sdk.NewCheck(0, ""), ErrExpirationTooClose()
 		}
 
-		// Transfer gasFee
-		_, err = checkGasFee(app_state, sender, utils.GetParams().RetireProgramProposalGas)
-		if err != nil {
-			return sdk.NewCheck(0, ""), err
+		if proposal.ResultBlockHeight != 0 {
+			return sdk.NewCheck(0, ""), errDecidedProposal(proposal)
 		}
-		// app_state.SubBalance(sender, gasFee.Int)
-	case TxUpgradeProgramPropose:
+	case TxDelegateVote:
 		validators := stake.GetCandidates().Validators()
-		if validators == nil || validators.Len() == 0 {
+		if !isValidatorOwner(validators, sender) {
 			return sdk.NewCheck(0, ""), ErrInvalidValidator()
 		}
-		for i, v := range validators {
-			if v.OwnerAddress == sender.String() {
-				break
-			}
-			if i+1 == len(validators) {
-				return sdk.NewCheck(0, ""), ErrInvalidValidator()
-			}
-		}
-
-		if txInner.ExpireBlockHeight != nil && ctx.BlockHeight() >= *txInner.ExpireBlockHeight {
-			return sdk.NewCheck(0, ""), ErrInvalidExpireBlockHeight()
-		}
-
-		var fileurlJson map[string][]string
-
-		if err = json.Unmarshal([]byte(txInner.FileUrl), &fileurlJson); err != nil {
-			return sdk.NewCheck(0, ""), ErrInvalidFileurlJson()
-		}
-
-		if _, ok := fileurlJson[utils.GOOSDIST]; !ok {
-			return sdk.NewCheck(0, ""), ErrNoFileurl()
-		}
-
-		var md5Json map[string]string
-
-		if err = json.Unmarshal([]byte(txInner.Md5), &md5Json); err != nil {
-			return sdk.NewCheck(0, ""), ErrInvalidMd5Json()
-		}
-
-		if _, ok := md5Json[utils.GOOSDIST]; !ok {
-			return sdk.NewCheck(0, ""), ErrNoMd5()
+		if *txInner.Delegatee == sender || !isValidatorOwner(validators, *txInner.Delegatee) {
+			return sdk.NewCheck(0, ""), ErrInvalidDelegatee()
 		}
-
-		// Transfer gasFee
-		_, err = checkGasFee(app_state, sender, utils.GetParams().UpgradeProgramProposalGas)
-		if err != nil {
-			return sdk.NewCheck(0, ""), err
-		}
-		// app_state.SubBalance(sender, gasFee.Int)
-	case TxVote:
-		validators := stake.GetCandidates().Validators()
-		if validators == nil || validators.Len() == 0 {
-			return sdk.NewCheck(0, ""), ErrInvalidValidator()
-		}
-		for i, v := range validators {
-			if v.OwnerAddress == sender.String() {
-				break
-			}
-			if i+1 == len(validators) {
-				return sdk.NewCheck(0, ""), ErrInvalidValidator()
-			}
+	case TxRevokeVoteDelegation:
+		if GetVoteDelegation(sender) == nil {
+			return sdk.NewCheck(0, ""), ErrNoVoteDelegation()
 		}
-
+	case TxCancelProposal:
 		proposal := GetProposalById(txInner.ProposalId)
 		if proposal == nil {
 			return sdk.NewCheck(0, ""), ErrInvalidParameter()
 		}
 
-		if proposal.ExpireBlockHeight > 0 && ctx.BlockHeight() >= proposal.ExpireBlockHeight - 2 {
-			return sdk.NewCheck(0, ""), ErrExpirationTooClose()
+		if proposal.Proposer == nil || *proposal.Proposer != sender {
+			return sdk.NewCheck(0, ""), ErrNotProposer()
 		}
 
 		if proposal.ResultBlockHeight != 0 {
-			if proposal.Result == "Approved" {
-				return sdk.NewCheck(0, ""), ErrApprovedProposal()
-			} else {
-				return sdk.NewCheck(0, ""), ErrRejectedProposal()
-			}
+			return sdk.NewCheck(0, ""), errDecidedProposal(proposal)
 		}
 	}
 
 	return
 }
 
+func isValidatorOwner(validators stake.Validators, addr common.Address) bool {
+	for _, v := range validators {
+		if v.OwnerAddress == addr.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func errDecidedProposal(proposal *Proposal) error {
+	switch proposal.Result {
+	case "Approved":
+		return ErrApprovedProposal()
+	case "Cancelled":
+		return ErrCancelledProposal()
+	case "Queued":
+		return ErrApprovedProposal()
+	}
+	return ErrRejectedProposal()
+}
+
 // DeliverTx executes the tx if valid
 func DeliverTx(ctx types.Context, store state.SimpleDB,
 	tx sdk.Tx, hash []byte) (res sdk.DeliverResult, err error) {
@@ -336,396 +146,268 @@ func DeliverTx(ctx types.Context, store state.SimpleDB,
 		return
 	}
 
+	if ptx, ok := tx.Unwrap().(proposeTx); ok {
+		return deliverPropose(ctx, sender, ptx.proposal(), hash)
+	}
+
 	switch txInner := tx.Unwrap().(type) {
-	case TxTransferFundPropose:
-		expireBlockHeight := ctx.BlockHeight() + int64(utils.GetParams().ProposalExpirePeriod)
-		var expireTimestamp int64
-		if txInner.ExpireTimestamp != nil {
-			expireTimestamp = *txInner.ExpireTimestamp
-			expireBlockHeight = 0
-		} else if txInner.ExpireBlockHeight != nil {
-			expireBlockHeight = *txInner.ExpireBlockHeight
-		}
-		hashJson, _ := json.Marshal(hash)
-		pp := NewTransferFundProposal(
-			string(hashJson[1:len(hashJson)-1]),
-			&sender,
-			ctx.BlockHeight(),
-			txInner.From,
-			txInner.To,
-			txInner.Amount,
-			txInner.Reason,
-			expireTimestamp,
-			expireBlockHeight,
-		)
-
-		amount := big.NewInt(0)
-		amount.SetString(txInner.Amount, 10)
-
-		app_state.SubBalance(*pp.Detail["from"].(*common.Address), amount)
-		app_state.AddBalance(utils.GovHoldAccount, amount)
-
-		SaveProposal(pp)
-
-		// Check gasFee  -- start
-		// get the sender
-		sender, err := getTxSender(ctx)
-		if err != nil {
-			return res, err
+	case TxVote:
+		var vote *Vote
+		if vote = GetVoteByPidAndVoter(txInner.ProposalId, sender.String()); vote != nil {
+			vote.Answer = txInner.Answer
+			vote.BlockHeight = ctx.BlockHeight()
+			UpdateVote(vote)
+		} else {
+			vote = NewVote(
+				txInner.ProposalId,
+				sender,
+				ctx.BlockHeight(),
+				txInner.Answer,
+			)
+			SaveVote(vote)
 		}
-		params := utils.GetParams()
-		gasUsed := params.TransferFundProposalGas
 
-		if gasFee, err := checkGasFee(app_state, sender, gasUsed); err != nil {
-			return res, err
-		} else {
-			res.GasFee = gasFee
-			res.GasUsed = int64(gasUsed)
-			// transfer gasFee
-			app_state.SubBalance(sender, gasFee)
-			app_state.AddBalance(utils.HoldAccount, gasFee)
+		proposal := GetProposalById(txInner.ProposalId)
+
+		checkResult := CheckProposal(txInner.ProposalId, &sender, ctx.BlockHeight())
+		if checkResult == "approved" || checkResult == "rejected" {
+			SettleDeposit(app_state, proposal, checkResult)
+			ResolveProposal(&ProposalContext{app_state, ctx.BlockHeight(), nil}, proposal, checkResult, false)
 		}
-		// Check gasFee  -- end
 
-		utils.PendingProposal.Add(pp.Id, pp.ExpireTimestamp, pp.ExpireBlockHeight)
+	case TxCancelProposal:
+		proposal := GetProposalById(txInner.ProposalId)
 
-		res.Data = hash
+		// the side effects of the submission are reverted as for a rejection
+		proposal.Content.OnReject(&ProposalContext{app_state, ctx.BlockHeight(), nil}, proposal)
 
-	case TxChangeParamPropose:
-		expireBlockHeight := ctx.BlockHeight() + int64(utils.GetParams().ProposalExpirePeriod)
-		var expireTimestamp int64
-		if txInner.ExpireTimestamp != nil {
-			expireTimestamp = *txInner.ExpireTimestamp
-			expireBlockHeight = 0
-		} else if txInner.ExpireBlockHeight != nil {
-			expireBlockHeight = *txInner.ExpireBlockHeight
-		}
-		hashJson, _ := json.Marshal(hash)
-		cp := NewChangeParamProposal(
-			string(hashJson[1:len(hashJson)-1]),
-			&sender,
-			ctx.BlockHeight(),
-			txInner.Name,
-			txInner.Value,
-			txInner.Reason,
-			expireTimestamp,
-			expireBlockHeight,
-		)
-		SaveProposal(cp)
-
-		// Check gasFee  -- start
-		// get the sender
-		sender, err := getTxSender(ctx)
-		if err != nil {
-			return res, err
-		}
-		params := utils.GetParams()
-		gasUsed := params.ChangeParamsProposalGas
+		SettleDeposit(app_state, proposal, "cancelled")
+		utils.PendingProposal.Del(proposal.Id)
+		UpdateProposalResult(proposal.Id, "Cancelled", "", ctx.BlockHeight())
 
-		if gasFee, err := checkGasFee(app_state, sender, gasUsed); err != nil {
-			return res, err
-		} else {
-			res.GasFee = gasFee
-			res.GasUsed = int64(gasUsed)
-			// transfer gasFee
-			app_state.SubBalance(sender, gasFee)
-			app_state.AddBalance(utils.HoldAccount, gasFee)
-		}
-		// Check gasFee  -- end
+	case TxDelegateVote:
+		SaveVoteDelegation(&VoteDelegation{sender, *txInner.Delegatee, ctx.BlockHeight()})
 
-		utils.PendingProposal.Add(cp.Id, cp.ExpireTimestamp, cp.ExpireBlockHeight)
+	case TxRevokeVoteDelegation:
+		DeleteVoteDelegation(sender)
+	}
 
-		res.Data = hash
+	return
+}
 
-	case TxDeployLibEniPropose:
-		expireBlockHeight := ctx.BlockHeight() + int64(utils.GetParams().ProposalExpirePeriod)
-		var expireTimestamp int64
-		if txInner.ExpireTimestamp != nil {
-			expireTimestamp = *txInner.ExpireTimestamp
-			expireBlockHeight = 0
-		} else if txInner.ExpireBlockHeight != nil {
-			expireBlockHeight = *txInner.ExpireBlockHeight
-		}
-		hashJson, _ := json.Marshal(hash)
-		dp := NewDeployLibEniProposal(
-			string(hashJson[1:len(hashJson)-1]),
-			&sender,
-			ctx.BlockHeight(),
-			txInner.Name,
-			txInner.Version,
-			txInner.FileUrl,
-			txInner.Md5,
-			txInner.Reason,
-			"init",
-			expireTimestamp,
-			expireBlockHeight,
-		)
-		SaveProposal(dp)
-
-		// Check gasFee  -- start
-		// get the sender
-		sender, err := getTxSender(ctx)
-		if err != nil {
-			return res, err
-		}
-		params := utils.GetParams()
-		gasUsed := params.DeployLibEniProposalGas
+// resolveTimelock returns the execution delay and the activation block height stored with a new proposal.
+// The delay defaults to the proposal_execution_delay param and is ignored if an activation block height is given.
+func resolveTimelock(executionDelay, activationBlockHeight *int64) (int64, int64) {
+	if activationBlockHeight != nil {
+		return 0, *activationBlockHeight
+	}
+	if executionDelay != nil {
+		return *executionDelay, 0
+	}
+	return int64(utils.GetParams().ProposalExecutionDelay), 0
+}
 
-		if gasFee, err := checkGasFee(app_state, sender, gasUsed); err != nil {
-			return res, err
-		} else {
-			res.GasFee = gasFee
-			res.GasUsed = int64(gasUsed)
-			// transfer gasFee
-			app_state.SubBalance(sender, gasFee)
-			app_state.AddBalance(utils.HoldAccount, gasFee)
-		}
-		// Check gasFee  -- end
+// QueueProposal puts an approved proposal into the queued state if it is timelocked,
+// it is then executed when the execute block height is reached.
+// It returns false if the proposal should be executed right away.
+func QueueProposal(p *Proposal, approvedBlockHeight int64) bool {
+	executeBlockHeight := approvedBlockHeight + p.ExecutionDelay
+	if p.ActivationBlockHeight > 0 {
+		executeBlockHeight = p.ActivationBlockHeight
+	}
+	if executeBlockHeight <= approvedBlockHeight {
+		return false
+	}
 
-		utils.PendingProposal.Add(dp.Id, dp.ExpireTimestamp, dp.ExpireBlockHeight)
+	queueProposal(p, executeBlockHeight, approvedBlockHeight)
+	return true
+}
 
-		res.Data = hash
+// queueProposal keeps an approved proposal pending until the execute block height
+func queueProposal(p *Proposal, executeBlockHeight, approvedBlockHeight int64) {
+	UpdateProposalQueued(p.Id, executeBlockHeight, approvedBlockHeight)
+	utils.PendingProposal.Del(p.Id)
+	utils.PendingProposal.Add(p.Id, 0, executeBlockHeight)
+}
 
-		DownloadLibEni(dp)
+// CheckProposal tallies the votes of a proposal against the quorum and thresholds of its type,
+// and persists the tally at the block height.
+// When called for a new vote of the voter, the proposal is only decided if the result can not be
+// changed any more by the validators who haven't voted yet. The delegated votes are left out of this
+// decision, as the delegators may still vote themselves.
+// When called with a nil voter, the proposal has reached its expiration and the votes cast are final.
+func CheckProposal(pid string, voter *common.Address, blockHeight int64) string {
+	proposal := GetProposalById(pid)
+	if proposal == nil {
+		return "not determined"
+	}
+	votes := GetVotesByPid(pid)
+	validators := getElectorate(pid)
 
-	case TxRetireProgramPropose:
-		expireBlockHeight := ctx.BlockHeight() + int64(utils.GetParams().ProposalExpirePeriod)
-		if txInner.ExpireBlockHeight != nil {
-			expireBlockHeight = *txInner.ExpireBlockHeight
-		}
-		hashJson, _ := json.Marshal(hash)
-		cp := NewRetireProgramProposal(
-			string(hashJson[1:len(hashJson)-1]),
-			&sender,
-			ctx.BlockHeight(),
-			version.Version,
-			txInner.PreservedValidators,
-			txInner.Reason,
-			expireBlockHeight,
-		)
-		SaveProposal(cp)
-
-		// Check gasFee  -- start
-		// get the sender
-		sender, err := getTxSender(ctx)
-		if err != nil {
-			return res, err
-		}
-		params := utils.GetParams()
-		gasUsed := params.RetireProgramProposalGas
+	if len(validators) == 0 {
+		return "no validator"
+	}
 
-		if gasFee, err := checkGasFee(app_state, sender, gasUsed); err != nil {
-			return res, err
-		} else {
-			res.GasFee = gasFee
-			res.GasUsed = int64(gasUsed)
-			// transfer gasFee
-			app_state.SubBalance(sender, gasFee)
-			app_state.AddBalance(utils.HoldAccount, gasFee)
-		}
-		// Check gasFee  -- end
+	tally := tallyVotes(pid, votes, validators, snapshotDelegations(validators), nil, blockHeight)
+	SaveTally(tally)
 
-		// check ahead one block
-		utils.PendingProposal.Add(cp.Id, cp.ExpireTimestamp, cp.ExpireBlockHeight - 1)
+	if voter == nil {
+		return decideProposal(tally, proposal.Type, true)
+	}
 
-		res.Data = hash
-	case TxUpgradeProgramPropose:
-		expireBlockHeight := ctx.BlockHeight() + int64(utils.GetParams().ProposalExpirePeriod)
-		if txInner.ExpireBlockHeight != nil {
-			expireBlockHeight = *txInner.ExpireBlockHeight
-		}
-		hashJson, _ := json.Marshal(hash)
-		cp := NewUpgradeProgramProposal(
-			string(hashJson[1:len(hashJson)-1]),
-			&sender,
-			ctx.BlockHeight(),
-			version.Version,
-			txInner.Name,
-			txInner.Version,
-			txInner.FileUrl,
-			txInner.Md5,
-			txInner.Reason,
-			expireBlockHeight,
-		)
-		SaveProposal(cp)
-
-		// Check gasFee  -- start
-		// get the sender
-		sender, err := getTxSender(ctx)
-		if err != nil {
-			return res, err
+	result := decideProposal(tallyVotes(pid, votes, validators, nil, nil, blockHeight), proposal.Type, false)
+	if result != "not determined" {
+		// To avoid repeated commit, let's recheck without the vote of the voter
+		if decideProposal(tallyVotes(pid, votes, validators, nil, voter, blockHeight), proposal.Type, false) == result {
+			return "not determined"
 		}
-		params := utils.GetParams()
-		gasUsed := params.UpgradeProgramProposalGas
+	}
+	return result
+}
 
-		if gasFee, err := checkGasFee(app_state, sender, gasUsed); err != nil {
-			return res, err
-		} else {
-			res.GasFee = gasFee
-			res.GasUsed = int64(gasUsed)
-			// transfer gasFee
-			app_state.SubBalance(sender, gasFee)
-			app_state.AddBalance(utils.HoldAccount, gasFee)
-		}
-		// Check gasFee  -- end
+// getElectorate returns the validators snapshotted at the creation of the proposal,
+// or the current validators with their current delegations for the proposals created before the snapshots were taken
+func getElectorate(pid string) []*SnapshotValidator {
+	if snapshot := GetValidatorSnapshot(pid); len(snapshot) > 0 {
+		return snapshot
+	}
 
-		utils.PendingProposal.Add(cp.Id, cp.ExpireTimestamp, cp.ExpireBlockHeight)
-		res.Data = hash
+	delegatees := make(map[string]string)
+	for _, d := range GetVoteDelegations() {
+		delegatees[d.Delegator.String()] = d.Delegatee.String()
+	}
 
-		DownloadProgramCmd(cp)
+	var electorate []*SnapshotValidator
+	for _, v := range stake.GetCandidates().Validators() {
+		electorate = append(electorate, &SnapshotValidator{pid, v.OwnerAddress, v.VotingPower, delegatees[v.OwnerAddress]})
+	}
+	return electorate
+}
 
-	case TxVote:
-		var vote *Vote
-		if vote = GetVoteByPidAndVoter(txInner.ProposalId, sender.String()); vote != nil {
-			vote.Answer = txInner.Answer
-			vote.BlockHeight = ctx.BlockHeight()
-			UpdateVote(vote)
-		} else {
-			vote = NewVote(
-				txInner.ProposalId,
-				sender,
-				ctx.BlockHeight(),
-				txInner.Answer,
-			)
-			SaveVote(vote)
+// snapshotDelegations returns the delegations of the votes recorded with the validators
+func snapshotDelegations(validators []*SnapshotValidator) (delegations []*VoteDelegation) {
+	for _, v := range validators {
+		if v.Delegatee != "" {
+			delegations = append(delegations, &VoteDelegation{
+				Delegator: common.HexToAddress(v.OwnerAddress),
+				Delegatee: common.HexToAddress(v.Delegatee),
+			})
 		}
+	}
+	return
+}
 
-		proposal := GetProposalById(txInner.ProposalId)
+// tallyVotes counts the voting power of the validators behind each answer.
+// A validator who hasn't voted is counted with the answer of the validator it delegated its vote to, if any.
+// The delegation is not transitive, the delegatee only passes on the power of its own vote,
+// and both of them have to be in the snapshot of the proposal.
+func tallyVotes(pid string, votes []*Vote, validators []*SnapshotValidator, delegations []*VoteDelegation, excluded *common.Address, blockHeight int64) *Tally {
+	answers := make(map[string]string)
+	for _, vo := range votes {
+		if excluded != nil && vo.Voter == *excluded {
+			continue
+		}
+		answers[vo.Voter.String()] = vo.Answer
+	}
 
-		checkResult := CheckProposal(txInner.ProposalId, &sender)
-
-		switch proposal.Type {
-		case TRANSFER_FUND_PROPOSAL:
-			amount := big.NewInt(0)
-			amount.SetString(proposal.Detail["amount"].(string), 10)
-			switch checkResult {
-			case "approved":
-				// as succeeded proposal only need to add balance to receiver,
-				// so the transfer should always be successful
-				// but we still use the reactor to keep the compatible with the old strategy
-				app_state.SubBalance(utils.GovHoldAccount, amount)
-				app_state.AddBalance(*proposal.Detail["to"].(*common.Address), amount)
-				UpdateProposalResult(proposal.Id, "Approved", "", ctx.BlockHeight())
-			case "rejected":
-				// as succeeded proposal only need to refund balance to sender,
-				// so the transfer should always be successful
-				// but we still use the reactor to keep the compatible with the old strategy
-				app_state.SubBalance(utils.GovHoldAccount, amount)
-				app_state.AddBalance(*proposal.Detail["from"].(*common.Address), amount)
-				UpdateProposalResult(proposal.Id, "Rejected", "", ctx.BlockHeight())
-			}
-			if checkResult == "approved" || checkResult == "rejected" {
-				utils.PendingProposal.Del(proposal.Id)
-			}
-		case CHANGE_PARAM_PROPOSAL:
-			switch checkResult {
-			case "approved":
-				utils.SetParam(proposal.Detail["name"].(string), proposal.Detail["value"].(string))
-				UpdateProposalResult(proposal.Id, "Approved", "", ctx.BlockHeight())
-			case "rejected":
-				UpdateProposalResult(proposal.Id, "Rejected", "", ctx.BlockHeight())
-			}
-			if checkResult == "approved" || checkResult == "rejected" {
-				utils.PendingProposal.Del(proposal.Id)
-			}
-		case DEPLOY_LIBENI_PROPOSAL:
-			switch checkResult {
-			case "approved":
-				UpdateProposalResult(proposal.Id, "Approved", "", ctx.BlockHeight())
-			case "rejected":
-				if proposal.Detail["status"] != "ready" {
-					CancelDownload(proposal, false)
-				}
-				utils.PendingProposal.Del(proposal.Id)
-				UpdateProposalResult(proposal.Id, "Rejected", "", ctx.BlockHeight())
-			}
-		case RETIRE_PROGRAM_PROPOSAL:
-			switch checkResult {
-			case "approved":
-				UpdateProposalResult(proposal.Id, "Approved", "", ctx.BlockHeight())
-			case "rejected":
-				utils.PendingProposal.Del(proposal.Id)
-				UpdateProposalResult(proposal.Id, "Rejected", "", ctx.BlockHeight())
-			}
-		case UPGRADE_PROGRAM_PROPOSAL:
-			switch checkResult {
-			case "approved":
-				UpdateProposalResult(proposal.Id, "Approved", "", ctx.BlockHeight())
-			case "rejected":
-				utils.PendingProposal.Del(proposal.Id)
-				UpdateProposalResult(proposal.Id, "Rejected", "", ctx.BlockHeight())
-			}
+	owners := make(map[string]bool)
+	for _, va := range validators {
+		owners[va.OwnerAddress] = true
+	}
+
+	delegated := make(map[string]string)
+	for _, d := range delegations {
+		if _, ok := answers[d.Delegator.String()]; ok {
+			continue
+		}
+		if answer, ok := answers[d.Delegatee.String()]; ok && owners[d.Delegatee.String()] {
+			delegated[d.Delegator.String()] = answer
 		}
 	}
 
-	return
+	var yesPower, noPower, abstainPower, vetoPower, totalPower int64
+	for _, va := range validators {
+		answer, ok := answers[va.OwnerAddress]
+		if !ok {
+			answer = delegated[va.OwnerAddress]
+		}
+		switch answer {
+		case VOTE_YES:
+			yesPower += va.VotingPower
+		case VOTE_NO:
+			noPower += va.VotingPower
+		case VOTE_ABSTAIN:
+			abstainPower += va.VotingPower
+		case VOTE_NO_WITH_VETO:
+			vetoPower += va.VotingPower
+		}
+		totalPower += va.VotingPower
+	}
+	return NewTally(pid, yesPower, noPower, abstainPower, vetoPower, totalPower, blockHeight)
 }
 
-func CheckProposal(pid string, voter *common.Address) string {
-	votes := GetVotesByPid(pid)
-	validators := stake.GetCandidates().Validators()
+func decideProposal(tally *Tally, ptype string, final bool) string {
+	quorum, threshold := ProposalThresholds(ptype)
+	veto := utils.GetParams().ProposalVetoThreshold
 
-	if validators == nil || validators.Len() == 0 {
-		return "no validator"
+	// compare returns the sign of a / b - r
+	compare := func(a, b int64, r sdk.Rat) int {
+		return new(big.Int).Mul(big.NewInt(a), r.Denom()).Cmp(new(big.Int).Mul(big.NewInt(b), r.Num()))
 	}
-
-	approvedPower := big.NewInt(0)
-	rejectedPower := big.NewInt(0)
-	allPower := big.NewInt(0)
-	voterPower := big.NewInt(0)
-	for _, va := range validators {
-		for _, vo := range votes {
-			// should check voter is still valid validator first
-			if vo.Voter.String() == va.OwnerAddress {
-				if strings.Compare(vo.Answer, "Y") == 0 {
-					approvedPower.Add(approvedPower, big.NewInt(va.VotingPower))
-				}
-				if strings.Compare(vo.Answer, "N") == 0 {
-					rejectedPower.Add(rejectedPower, big.NewInt(va.VotingPower))
-				}
-			}
-		}
-		if voter != nil && voter.String() == va.OwnerAddress {
-			voterPower = big.NewInt(va.VotingPower)
+	atLeast := func(a, b int64, r sdk.Rat) bool { return compare(a, b, r) >= 0 }
+	// a simple majority has to exceed the half of the votes, the supermajorities are reached inclusively
+	passes := func(a, b int64, r sdk.Rat) bool {
+		if r.Equal(sdk.NewRat(1, 2)) {
+			return compare(a, b, r) > 0
 		}
-		allPower.Add(allPower, big.NewInt(va.VotingPower))
+		return atLeast(a, b, r)
 	}
 
-	allPower.Mul(allPower, big.NewInt(2))
-	three := big.NewInt(3)
-	voterPower.Mul(voterPower, three)
-	approvedPower.Mul(approvedPower, three)
-	rejectedPower.Mul(rejectedPower, three)
+	// votedPower / totalPower >= quorum
+	votedPower := tally.VotedPower()
+	if !atLeast(votedPower, tally.TotalPower, quorum) {
+		return "not determined"
+	}
 
-	if approvedPower.Cmp(allPower) >= 0 {
-		// To avoid repeated commit, let's recheck with count of voters - voter
-		if voter == nil || approvedPower.Sub(approvedPower, voterPower).Cmp(allPower) < 0 {
+	if utils.GetParams().ProposalThresholdOfTotalPower {
+		// the yes or the no votes have to reach the threshold of the total voting power,
+		// the vetoes count as no votes
+		if tally.TotalPower == 0 {
+			return "not determined"
+		}
+		if passes(tally.YesPower, tally.TotalPower, threshold) {
 			return "approved"
 		}
-	} else if rejectedPower.Cmp(allPower) >= 0 {
-		// To avoid repeated commit, let's recheck with count of voters - voter
-		if voter == nil || rejectedPower.Sub(rejectedPower, voterPower).Cmp(allPower) < 0 {
+		if passes(tally.NoPower+tally.VetoPower, tally.TotalPower, threshold) {
 			return "rejected"
 		}
+		return "not determined"
 	}
-	return "not determined"
-}
 
-type ProposalReactor struct {
-	ProposalId  string
-	BlockHeight int64
-	Result      string
-}
+	// abstentions count toward the quorum only
+	castPower, basePower := votedPower, tally.YesPower+tally.NoPower+tally.VetoPower
+	if !final {
+		// the validators who haven't voted yet may still vote,
+		// so the proposal is decided only if the remaining votes can't change the result
+		castPower, basePower = tally.TotalPower, tally.TotalPower-tally.AbstainPower
+	}
 
-func (pr ProposalReactor) React(result, msg string) {
-	if result == "success" {
-		// If the default result is not set, then do nothing
-		if pr.Result == "" {
-			return
-		}
-		result = pr.Result
+	// the veto has to exceed its threshold
+	if compare(tally.VetoPower, castPower, veto) > 0 {
+		return "rejected"
 	}
-	UpdateProposalResult(pr.ProposalId, result, msg, pr.BlockHeight)
+	if basePower == 0 {
+		return "rejected"
+	}
+	// the yes votes have to reach the pass threshold
+	if passes(tally.YesPower, basePower, threshold) {
+		return "approved"
+	}
+	// even if all the remaining votes were yes
+	if !passes(basePower-tally.NoPower-tally.VetoPower, basePower, threshold) {
+		return "rejected"
+	}
+	return "not determined"
 }
 
 // get the sender from the ctx and ensure it matches the tx pubkey
@@ -749,99 +431,104 @@ func checkGasFee(state *ethState.StateDB, address common.Address, gas uint64) (*
 	return gasFee, nil
 }
 
-func getOTAInfo(p *Proposal) *eni.OTAInfo {
-	var fileurlJson map[string][]string
-	if err := json.Unmarshal([]byte(p.Detail["fileurl"].(string)), &fileurlJson); err != nil {
-		return nil
+// checkProposalFee checks the proposer can afford both the gas fee and the deposit of a new proposal
+func checkProposalFee(state *ethState.StateDB, address common.Address, gas uint64) (*big.Int, error) {
+	gasFee, err := checkGasFee(state, address, gas)
+	if err != nil {
+		return nil, err
 	}
 
-	var md5Json map[string]string
-	if err := json.Unmarshal([]byte(p.Detail["md5"].(string)), &md5Json); err != nil {
-		return nil
+	if state.GetBalance(address).Cmp(new(big.Int).Add(gasFee, proposalDeposit())) < 0 {
+		return nil, ErrInsufficientBalance()
 	}
 
-	fileurl, ok := fileurlJson[utils.GOOSDIST]
+	return gasFee, nil
+}
+
+func proposalDeposit() *big.Int {
+	deposit, ok := new(big.Int).SetString(utils.GetParams().MinProposalDeposit, 10)
 	if !ok {
-		return nil
+		return big.NewInt(0)
+	}
+	return deposit
+}
+
+// escrowDeposit moves the deposit of a new proposal from the proposer to the governance hold account
+func escrowDeposit(state *ethState.StateDB, proposer common.Address) string {
+	deposit := proposalDeposit()
+	state.SubBalance(proposer, deposit)
+	state.AddBalance(utils.GovHoldAccount, deposit)
+	return deposit.String()
+}
+
+// SettleDeposit releases the deposit of a proposal once it is no longer pending,
+// it goes to the mint account if the proposal has been rejected, i.e. it is burned, and is refunded to the proposer otherwise
+func SettleDeposit(state *ethState.StateDB, p *Proposal, checkResult string) {
+	deposit, ok := new(big.Int).SetString(p.Deposit, 10)
+	if !ok || deposit.Sign() <= 0 {
+		return
 	}
 
-	md5, ok := md5Json[utils.GOOSDIST]
+	state.SubBalance(utils.GovHoldAccount, deposit)
+	if checkResult == "rejected" {
+		state.AddBalance(utils.MintAccount, deposit)
+		return
+	}
+	state.AddBalance(*p.Proposer, deposit)
+}
+
+// getOTAInfo returns the download info of the release of a deploy libeni or upgrade program proposal
+func getOTAInfo(p *Proposal) *eni.OTAInfo {
+	rc, ok := p.Content.(releaseContent)
 	if !ok {
 		return nil
 	}
+	return rc.release().otaInfo()
+}
 
-	return &eni.OTAInfo{
-		p.Detail["name"].(string),
-		p.Detail["version"].(string),
-		fileurl,
-		md5,
+// getChecksum returns the checksum of the release of a deploy libeni or upgrade program proposal
+func getChecksum(p *Proposal) *types.ArtifactChecksum {
+	rc, ok := p.Content.(releaseContent)
+	if !ok {
+		return nil
 	}
+	return rc.release().checksum()
 }
 
+// DownloadLibEni starts the download of the library of a deploy libeni proposal,
+// it is retried as configured until it succeeds or the proposal is resolved
 func DownloadLibEni(p *Proposal) {
 	oi := getOTAInfo(p)
 	if oi == nil {
 		return
 	}
-
-	result := make(chan bool)
-
-	go func() {
-		if r := <-result; r {
-			if r, ok := cancelDownload[p.Id]; ok {
-				delete(cancelDownload, p.Id)
-				if r {
-					RegisterLibEni(p)
-					UpdateDeployLibEniStatus(p.Id, "deployed")
-				} else {
-					UpdateDeployLibEniStatus(p.Id, "ready")
-				}
-			} else {
-				UpdateDeployLibEniStatus(p.Id, "ready")
-			}
-		} else {
-			if r, ok := cancelDownload[p.Id]; ok {
-				delete(cancelDownload, p.Id)
-				if r {
-					UpdateDeployLibEniStatus(p.Id, "collapsed") // failed, but proposal has been approved
-				} else {
-					UpdateDeployLibEniStatus(p.Id, "failed")
-				}
-			} else {
-				UpdateDeployLibEniStatus(p.Id, "failed")
-			}
-		}
-	}()
-
-	go func() {
-		for {
-			if _, ok := cancelDownload[p.Id]; ok {
-				result <- false
-				break
-			}
-			if err := OTAInstance.DownloadInfo(*oi); err == nil {
-				result <- true
-				break
-			}
-			if _, ok := cancelDownload[p.Id]; ok {
-				result <- false
-				break
-			}
-			time.Sleep(10 * time.Second)
-		}
-	}()
+	downloads.start(p.Id, *oi, getChecksum(p), utils.GetParams().OtaPublisherKeyList())
 }
 
+// CancelDownload stops the download once the proposal is resolved, bpanic is set if it has been approved.
+// The library is then registered once downloaded, against the publisher keys at the approval.
 func CancelDownload(p *Proposal, bpanic bool) {
-	cancelDownload[p.Id] = bpanic
+	downloads.cancel(p.Id, bpanic, utils.GetParams().OtaPublisherKeyList())
 }
 
-func RegisterLibEni(p *Proposal) {
+func RegisterLibEni(p *Proposal) error {
 	oi := getOTAInfo(p)
 	if oi == nil {
-		return
+		return errors.New("unknown error")
+	}
+	return registerLibEni(*oi, getChecksum(p), utils.GetParams().OtaPublisherKeyList())
+}
+
+// registerLibEni registers a downloaded library, the signature of a release which is not legacy
+// is verified again as the publisher keys may have changed since the submission
+func registerLibEni(oi eni.OTAInfo, checksum *types.ArtifactChecksum, publisherKeys []string) error {
+	if !checksum.Legacy() {
+		if err := checksum.VerifySignature(publisherKeys); err != nil {
+			return err
+		}
 	}
-	OTAInstance.Register(*oi)
+	OTAInstance.Register(oi)
+	return nil
 }
 
 func DestroyLibEni(p *Proposal) {
@@ -866,11 +553,11 @@ func KillProgramCmd(p *Proposal) error {
 
 // DownloadProgramCmd download new program version
 func DownloadProgramCmd(p *Proposal) error {
-	oi := getOTAInfo(p)
-	if oi == nil {
+	oi, checksum := getOTAInfo(p), getChecksum(p)
+	if oi == nil || checksum == nil {
 		return errors.New("unknown error")
 	}
-	info := &types.CmdInfo{Name: oi.LibName, Version: oi.Version, DownloadURLs:oi.Url, MD5: oi.Checksum}
+	info := newCmdInfo(oi, checksum)
 	reply := &types.MonitorResponse{}
 	err := callRpc("Monitor.Download", info, reply)
 	if err != nil {
@@ -882,11 +569,11 @@ func DownloadProgramCmd(p *Proposal) error {
 
 // UpgradeProgramCmd upgrade new program version
 func UpgradeProgramCmd(p *Proposal) error {
-	oi := getOTAInfo(p)
-	if oi == nil {
+	oi, checksum := getOTAInfo(p), getChecksum(p)
+	if oi == nil || checksum == nil {
 		return errors.New("unknown error")
 	}
-	info := &types.CmdInfo{Name: oi.LibName, Version: oi.Version, DownloadURLs:oi.Url, MD5: oi.Checksum}
+	info := newCmdInfo(oi, checksum)
 	reply := &types.MonitorResponse{}
 	err := callRpc("Monitor.Upgrade", info, reply)
 	if err != nil {
@@ -895,6 +582,19 @@ func UpgradeProgramCmd(p *Proposal) error {
 	return nil
 }
 
+// newCmdInfo returns the release info sent to the monitor, which verifies the binary before the swap
+func newCmdInfo(oi *eni.OTAInfo, checksum *types.ArtifactChecksum) *types.CmdInfo {
+	return &types.CmdInfo{
+		Name:          oi.LibName,
+		Version:       oi.Version,
+		DownloadURLs:  oi.Url,
+		MD5:           checksum.MD5,
+		Sha256:        checksum.Sha256,
+		Signature:     checksum.Signature,
+		PublisherKeys: utils.GetParams().OtaPublisherKeyList(),
+	}
+}
+
 func callRpc(serviceMethod string, info *types.CmdInfo, reply *types.MonitorResponse) error {
 	client, err := rpc.DialHTTP("tcp", "127.0.0.1:26650")
 	if err != nil {
@@ -0,0 +1,110 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/utils"
+)
+
+func TestDecideProposal(t *testing.T) {
+	assert := assert.New(t)
+	params := utils.DefaultParams()
+	params.ProposalThresholdOfTotalPower = false
+	params.TransferFundProposalThreshold = sdk.NewRat(1, 2)
+	params.UpgradeProgramProposalThreshold = sdk.NewRat(3, 4)
+	utils.SetParams(params)
+	defer utils.SetParams(utils.DefaultParams())
+
+	cases := []struct {
+		name                   string
+		ptype                  string
+		yes, no, abstain, veto int64
+		total                  int64
+		final                  bool
+		expected               string
+	}{
+		{"quorum not reached", CHANGE_PARAM_PROPOSAL, 1, 0, 0, 0, 3, false, "not determined"},
+		{"threshold reached early", CHANGE_PARAM_PROPOSAL, 2, 0, 0, 0, 3, false, "approved"},
+		{"threshold still reachable", CHANGE_PARAM_PROPOSAL, 1, 1, 0, 0, 3, false, "not determined"},
+		{"threshold no longer reachable", CHANGE_PARAM_PROPOSAL, 0, 2, 0, 0, 3, false, "rejected"},
+		{"threshold reached at expiry", CHANGE_PARAM_PROPOSAL, 2, 1, 0, 0, 3, true, "approved"},
+		{"threshold missed at expiry", CHANGE_PARAM_PROPOSAL, 1, 1, 1, 0, 3, true, "rejected"},
+		{"all abstain", CHANGE_PARAM_PROPOSAL, 0, 0, 3, 0, 3, true, "rejected"},
+		{"veto exceeded", CHANGE_PARAM_PROPOSAL, 1, 0, 0, 2, 3, true, "rejected"},
+		{"veto reached only", CHANGE_PARAM_PROPOSAL, 2, 0, 0, 1, 3, true, "approved"},
+		{"tie on a simple majority", TRANSFER_FUND_PROPOSAL, 1, 1, 0, 0, 2, true, "rejected"},
+		{"tie still breakable", TRANSFER_FUND_PROPOSAL, 2, 1, 0, 0, 4, false, "not determined"},
+		{"simple majority", TRANSFER_FUND_PROPOSAL, 2, 1, 0, 0, 3, true, "approved"},
+		{"simple majority no longer reachable", TRANSFER_FUND_PROPOSAL, 1, 2, 0, 0, 4, false, "rejected"},
+		{"three quarters reached", UPGRADE_PROGRAM_PROPOSAL, 3, 0, 0, 0, 4, false, "approved"},
+		{"three quarters still reachable", UPGRADE_PROGRAM_PROPOSAL, 2, 1, 0, 0, 4, false, "not determined"},
+		{"three quarters missed", UPGRADE_PROGRAM_PROPOSAL, 2, 1, 0, 0, 3, true, "rejected"},
+	}
+
+	for _, c := range cases {
+		tally := NewTally("pid", c.yes, c.no, c.abstain, c.veto, c.total, 1)
+		assert.Equal(c.expected, decideProposal(tally, c.ptype, c.final), c.name)
+	}
+}
+
+// the default params keep deciding the proposals by the yes votes of 2/3 of the total voting power
+func TestDecideProposalByDefault(t *testing.T) {
+	utils.SetParams(utils.DefaultParams())
+
+	decide := func(yes, no, abstain, veto, total int64, final bool) string {
+		return decideProposal(NewTally("pid", yes, no, abstain, veto, total, 1), TRANSFER_FUND_PROPOSAL, final)
+	}
+
+	assert.Equal(t, "approved", decide(2, 0, 0, 0, 3, false))
+	assert.Equal(t, "approved", decide(2, 1, 0, 0, 3, true))
+	// 2/3 of the votes cast are not enough
+	assert.Equal(t, "not determined", decide(2, 1, 0, 0, 4, true))
+	assert.Equal(t, "not determined", decide(4, 1, 1, 0, 9, false))
+	assert.Equal(t, "rejected", decide(1, 2, 0, 0, 3, false))
+	assert.Equal(t, "rejected", decide(0, 1, 0, 1, 3, false))
+	// a veto alone doesn't reject the proposal
+	assert.Equal(t, "not determined", decide(1, 0, 1, 1, 3, true))
+	assert.Equal(t, "not determined", decide(0, 0, 0, 0, 0, true))
+}
+
+func TestTallyVotes(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b, c := common.HexToAddress("0xa"), common.HexToAddress("0xb"), common.HexToAddress("0xc")
+	validators := []*SnapshotValidator{
+		{"pid", a.String(), 10, b.String()},
+		{"pid", b.String(), 20, ""},
+		{"pid", c.String(), 30, common.HexToAddress("0xd").String()},
+	}
+	delegations := snapshotDelegations(validators)
+	assert.Equal(2, len(delegations))
+
+	cases := []struct {
+		name                        string
+		votes                       []*Vote
+		delegations                 []*VoteDelegation
+		excluded                    *common.Address
+		yes, no, abstain, veto, all int64
+	}{
+		{"no votes", nil, delegations, nil, 0, 0, 0, 0, 60},
+		{"delegated vote", []*Vote{NewVote("pid", b, 1, VOTE_YES)}, delegations, nil, 30, 0, 0, 0, 60},
+		{"delegations left out", []*Vote{NewVote("pid", b, 1, VOTE_YES)}, nil, nil, 20, 0, 0, 0, 60},
+		{"own vote of the delegator", []*Vote{NewVote("pid", b, 1, VOTE_YES), NewVote("pid", a, 1, VOTE_NO)}, delegations, nil, 20, 10, 0, 0, 60},
+		{"delegatee out of the snapshot", []*Vote{NewVote("pid", c, 1, VOTE_ABSTAIN)}, delegations, nil, 0, 0, 30, 0, 60},
+		{"excluded voter", []*Vote{NewVote("pid", b, 1, VOTE_NO_WITH_VETO)}, delegations, &b, 0, 0, 0, 0, 60},
+		{"veto", []*Vote{NewVote("pid", b, 1, VOTE_NO_WITH_VETO)}, delegations, nil, 0, 0, 0, 30, 60},
+	}
+
+	for _, tc := range cases {
+		tally := tallyVotes("pid", tc.votes, validators, tc.delegations, tc.excluded, 1)
+		assert.Equal(tc.yes, tally.YesPower, tc.name)
+		assert.Equal(tc.no, tally.NoPower, tc.name)
+		assert.Equal(tc.abstain, tally.AbstainPower, tc.name)
+		assert.Equal(tc.veto, tally.VetoPower, tc.name)
+		assert.Equal(tc.all, tally.TotalPower, tc.name)
+	}
+}
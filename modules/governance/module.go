@@ -0,0 +1,169 @@
+package governance
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/sdk/state"
+	"github.com/vangjvn/devchain/types"
+)
+
+// Module plugs the governance module into the application
+type Module struct{}
+
+func NewModule() Module {
+	return Module{}
+}
+
+func (Module) Name() string {
+	return governanceModuleName
+}
+
+func (Module) InitGenesis(store state.SimpleDB, genDoc *types.GenesisDoc) error {
+	return nil
+}
+
+// Load restores the pending proposals, resumes the downloads of their libraries and loads the active emergency pause
+func (Module) Load() {
+	LoadPendingProposals()
+	LoadPause()
+}
+
+// AfterCommit reloads the active emergency pause, which is enforced from the next block
+func (Module) AfterCommit() {
+	LoadPause()
+}
+
+func (Module) CheckTx(ctx types.Context, store state.SimpleDB, tx sdk.Tx) (sdk.CheckResult, error) {
+	return CheckTx(ctx, store, tx)
+}
+
+func (Module) DeliverTx(ctx types.Context, store state.SimpleDB, tx sdk.Tx, hash []byte) (sdk.DeliverResult, error) {
+	return DeliverTx(ctx, store, tx, hash)
+}
+
+// CheckEthTx rejects the EVM transactions paused by an emergency pause
+func (Module) CheckEthTx(to *common.Address, blockHeight int64) error {
+	return CheckPause(to, blockHeight)
+}
+
+func (Module) QueryRoutes() map[string]sdk.QueryHandler {
+	return map[string]sdk.QueryHandler{
+		"/governance/proposals": func(data []byte) ([]byte, error) {
+			return json.Marshal(QueryProposals())
+		},
+		"/governance/proposal": func(data []byte) ([]byte, error) {
+			proposal := QueryProposalById(string(data))
+			if proposal == nil {
+				return []byte{}, nil
+			}
+			return json.Marshal(proposal)
+		},
+		// data is a json encoded ProposalFilter
+		"/governance/proposals/filter": func(data []byte) ([]byte, error) {
+			var filter ProposalFilter
+			if err := json.Unmarshal(data, &filter); err != nil {
+				return nil, err
+			}
+			return json.Marshal(QueryProposalsByFilter(filter))
+		},
+		"/governance/votes": func(data []byte) ([]byte, error) {
+			return json.Marshal(QueryVotesByPid(string(data)))
+		},
+		"/governance/votes/voter": func(data []byte) ([]byte, error) {
+			return json.Marshal(QueryVotesByVoter(string(data)))
+		},
+		"/governance/snapshot": func(data []byte) ([]byte, error) {
+			return json.Marshal(QueryValidatorSnapshot(string(data)))
+		},
+		"/governance/delegations": func(data []byte) ([]byte, error) {
+			return json.Marshal(QueryVoteDelegations())
+		},
+		"/governance/events": func(data []byte) ([]byte, error) {
+			return json.Marshal(QueryEventsByPid(string(data)))
+		},
+		"/governance/grant": func(data []byte) ([]byte, error) {
+			grant := QueryGrant(string(data))
+			if grant == nil {
+				return []byte{}, nil
+			}
+			return json.Marshal(grant)
+		},
+		// data is the status of the grants, or a zero byte for all of them
+		"/governance/grants": func(data []byte) ([]byte, error) {
+			status := ""
+			if len(data) > 0 && data[0] != 0 {
+				status = string(data)
+			}
+			return json.Marshal(QueryGrants(status))
+		},
+		"/governance/contract_call_receipt": func(data []byte) ([]byte, error) {
+			receipt := QueryContractCallReceipt(string(data))
+			if receipt == nil {
+				return []byte{}, nil
+			}
+			return json.Marshal(receipt)
+		},
+		"/governance/pause": func(data []byte) ([]byte, error) {
+			pause := GetActivePause()
+			if pause == nil {
+				return []byte{}, nil
+			}
+			return json.Marshal(pause)
+		},
+		"/governance/pauses": func(data []byte) ([]byte, error) {
+			return json.Marshal(QueryPauses())
+		},
+		"/governance/consensus_params": func(data []byte) ([]byte, error) {
+			return json.Marshal(GetConsensusParams())
+		},
+		"/governance/download": func(data []byte) ([]byte, error) {
+			progress := QueryDownload(string(data))
+			if progress == nil {
+				return []byte{}, nil
+			}
+			return json.Marshal(progress)
+		},
+		"/governance/downloads": func(data []byte) ([]byte, error) {
+			return json.Marshal(QueryDownloads())
+		},
+		"/governance/tally": func(data []byte) ([]byte, error) {
+			tally := QueryTallyByPid(string(data))
+			if tally == nil {
+				return []byte{}, nil
+			}
+			return json.Marshal(tally)
+		},
+	}
+}
+
+func (Module) BeginBlock(ctx types.Context, store state.SimpleDB, req abci.RequestBeginBlock) {
+	setWorkingHeight(ctx.BlockHeight())
+	ExpirePause(ctx.BlockHeight())
+}
+
+// EndBlock releases the grant tranches and returns the consensus params changed by the proposals approved in the previous block
+func (Module) EndBlock(ctx types.Context, store state.SimpleDB, req abci.RequestEndBlock) ([]abci.Validator, *abci.ConsensusParams, error) {
+	ReleaseGrants(ctx.EthappState(), ctx.BlockHeight())
+	return nil, ApplyConsensusParams(ctx.BlockHeight()), nil
+}
+
+func (Module) ResumeRetirement(lastBlockHeight int64) (retired, relaunched bool) {
+	return ResumeRetirement(lastBlockHeight)
+}
+
+func (Module) Retire() ([]abci.Validator, bool, error) {
+	return Retire()
+}
+
+func (Module) SetDeliverSqlTx(tx *sql.Tx) {
+	SetDeliverSqlTx(tx)
+}
+
+func (Module) ResetDeliverSqlTx() {
+	ResetDeliverSqlTx()
+}
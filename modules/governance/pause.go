@@ -0,0 +1,197 @@
+package governance
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// activePause caches the active emergency pause, which is checked for every EVM transaction.
+// It is only loaded from the committed state, when the node starts and after each block is committed,
+// so a pause started in a block is enforced from the next block and never outlives a rolled back block.
+var (
+	activePause *Pause
+	pauseMtx    sync.RWMutex
+)
+
+func setActivePause(p *Pause) {
+	pauseMtx.Lock()
+	defer pauseMtx.Unlock()
+	activePause = p
+}
+
+// GetActivePause returns the active emergency pause, or nil if there is none
+func GetActivePause() *Pause {
+	pauseMtx.RLock()
+	defer pauseMtx.RUnlock()
+	return activePause
+}
+
+// CheckPause returns ErrPaused if an EVM transaction to the address is paused at the block height,
+// to is nil for a contract creation
+func CheckPause(to *common.Address, blockHeight int64) error {
+	if p := GetActivePause(); p != nil && p.Covers(to, blockHeight) {
+		return ErrPaused()
+	}
+	return nil
+}
+
+// LoadPause reloads the active emergency pause from the committed state
+func LoadPause() {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	setActivePause(getActivePause(tx))
+}
+
+// getActivePause reads the active emergency pause within the tx, including the changes of the block being delivered
+func getActivePause(tx *sql.Tx) *Pause {
+	return getPause(tx, " where status = ?", PAUSE_STATUS_ACTIVE)
+}
+
+func savePause(tx *sql.Tx, p *Pause) {
+	stmt, err := tx.Prepare("insert into governance_pause(proposal_id, contracts, start_block_height, end_block_height, status, lifted_by, block_height, hash) values(?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	contracts, _ := json.Marshal(p.Contracts)
+	_, err = stmt.Exec(p.ProposalId, string(contracts), p.StartBlockHeight, p.EndBlockHeight, p.Status, p.LiftedBy, p.BlockHeight, common.Bytes2Hex(p.Hash()))
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+func updatePause(tx *sql.Tx, p *Pause) {
+	stmt, err := tx.Prepare("update governance_pause set status = ?, lifted_by = ?, block_height = ?, hash = ? where proposal_id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(p.Status, p.LiftedBy, p.BlockHeight, common.Bytes2Hex(p.Hash()), p.ProposalId)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+// getPauses loads the pauses, clause is appended to the query, e.g. a where clause
+func getPauses(tx *sql.Tx, clause string, args ...interface{}) (pauses []*Pause) {
+	rows, err := tx.Query("select proposal_id, contracts, start_block_height, end_block_height, status, lifted_by, block_height from governance_pause"+clause, args...)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var contracts string
+		p := &Pause{}
+		err = rows.Scan(&p.ProposalId, &contracts, &p.StartBlockHeight, &p.EndBlockHeight, &p.Status, &p.LiftedBy, &p.BlockHeight)
+		if err != nil {
+			panic(err)
+		}
+		if err = json.Unmarshal([]byte(contracts), &p.Contracts); err != nil {
+			panic(err)
+		}
+		pauses = append(pauses, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return
+}
+
+func getPause(tx *sql.Tx, clause string, args ...interface{}) *Pause {
+	pauses := getPauses(tx, clause, args...)
+	if len(pauses) == 0 {
+		return nil
+	}
+	return pauses[0]
+}
+
+// QueryPauses returns all the emergency pauses, the latest first
+func QueryPauses() []*Pause {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	return getPauses(tx, " order by start_block_height desc")
+}
+
+// StartPause starts the emergency pause of an approved proposal, it replaces the active pause if any.
+// The EVM transactions are checked against it from the next block.
+func StartPause(pid string, c *PauseContent, blockHeight int64) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	if active := getActivePause(txWrapper.tx); active != nil {
+		liftPause(txWrapper.tx, active, pid, blockHeight)
+	}
+
+	p := &Pause{
+		pid,
+		c.Contracts,
+		blockHeight,
+		blockHeight + c.duration(),
+		PAUSE_STATUS_ACTIVE,
+		"",
+		blockHeight,
+	}
+	savePause(txWrapper.tx, p)
+}
+
+// LiftPause lifts the active emergency pause by an approved unpause proposal.
+// It returns false if there is no active pause.
+func LiftPause(pid string, blockHeight int64) bool {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	active := getActivePause(txWrapper.tx)
+	if active == nil {
+		return false
+	}
+
+	liftPause(txWrapper.tx, active, pid, blockHeight)
+	return true
+}
+
+func liftPause(tx *sql.Tx, p *Pause, pid string, blockHeight int64) {
+	lifted := *p
+	lifted.Status = PAUSE_STATUS_LIFTED
+	lifted.LiftedBy = pid
+	lifted.BlockHeight = blockHeight
+	updatePause(tx, &lifted)
+
+	saveEvent(tx, p.ProposalId, EVENT_PAUSE_LIFTED, "", pid, blockHeight)
+}
+
+// ExpirePause closes the active emergency pause once the block height reaches its end
+func ExpirePause(blockHeight int64) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	active := getActivePause(txWrapper.tx)
+	if active == nil || blockHeight < active.EndBlockHeight {
+		return
+	}
+
+	expired := *active
+	expired.Status = PAUSE_STATUS_EXPIRED
+	expired.BlockHeight = blockHeight
+	updatePause(txWrapper.tx, &expired)
+
+	saveEvent(txWrapper.tx, active.ProposalId, EVENT_PAUSE_EXPIRED, "", "", blockHeight)
+}
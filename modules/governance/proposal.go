@@ -0,0 +1,329 @@
+package governance
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethState "github.com/ethereum/go-ethereum/core/state"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/vangjvn/devchain/modules/stake"
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+// ProposalContent is the type specific part of a proposal.
+// A proposal type is added by implementing its content and registering it with RegisterProposalType,
+// the content is then submitted, stored, executed and reverted without any switch on the proposal type.
+type ProposalContent interface {
+	// Validate checks the content against the current state when the proposal is submitted
+	Validate(ctx types.Context) error
+	// Execute applies the approved proposal, the returned message is recorded as the result message
+	Execute(ctx *ProposalContext, p *Proposal) string
+	// OnReject reverts the side effects of the submission when the proposal is rejected or cancelled
+	OnReject(ctx *ProposalContext, p *Proposal)
+	// OnExpire reverts the side effects of the submission when the proposal expires undecided
+	OnExpire(ctx *ProposalContext, p *Proposal)
+	// Save stores the content in the detail table of the proposal type
+	Save(tx *sql.Tx, pid string)
+	// Load restores the content from the detail table, it returns false if the detail is missing
+	Load(tx *sql.Tx, pid string) bool
+}
+
+// DeferredContent is implemented by the contents which are executed at the expiration of the proposal,
+// e.g. to upgrade all nodes at the same height. An approval before the expiration is only recorded.
+type DeferredContent interface {
+	ExecuteAtExpiration()
+}
+
+// SubmitContent is implemented by the contents with side effects at the submission of the proposal,
+// e.g. escrowing the amount to transfer, which are reverted by OnReject and OnExpire
+type SubmitContent interface {
+	OnSubmit(ctx *ProposalContext, p *Proposal)
+}
+
+// TimelockedContent is implemented by the contents whose execution can be timelocked,
+// an approval is queued for the execution delay of the proposal or until its activation block height
+type TimelockedContent interface {
+	Timelocked()
+}
+
+// CheckAheadContent is implemented by the contents whose expiration is processed one block ahead,
+// e.g. for the nodes to stop at the expiration of an approved retire_program proposal
+type CheckAheadContent interface {
+	CheckAhead()
+}
+
+// CommitContent is implemented by the contents which are executed by the EVM, against the state at the commit of the block.
+// An approval by a vote is queued until the commit of the same block.
+type CommitContent interface {
+	ExecuteAtCommit()
+}
+
+// ContractCaller executes a message call against the state at the commit of the block,
+// the logs of the call are recorded under thash
+type ContractCaller interface {
+	Call(from, to common.Address, value *big.Int, data []byte, gas uint64, thash common.Hash) (ret []byte, logs []*ethTypes.Log, gasUsed uint64, err error)
+}
+
+// ProposalContext is the state a proposal is resolved against,
+// EVM is only available at the commit of the block
+type ProposalContext struct {
+	State       *ethState.StateDB
+	BlockHeight int64
+	EVM         ContractCaller
+}
+
+// ProposalType is the registration of a proposal type
+type ProposalType struct {
+	// NewContent returns the empty content of the type
+	NewContent func() ProposalContent
+	// Gas returns the gas charged for the submission of a proposal of the type
+	Gas func(params *utils.Params) uint64
+	// Thresholds returns the share of the voting power which has to vote on a proposal of the type,
+	// and the share of the votes cast which has to be yes for it to pass
+	Thresholds func(params *utils.Params) (quorum, threshold sdk.Rat)
+}
+
+var proposalTypes = make(map[string]ProposalType)
+
+// RegisterProposalType registers a proposal type,
+// it is called from the init function of the file implementing the type.
+func RegisterProposalType(ptype string, t ProposalType) {
+	if _, ok := proposalTypes[ptype]; ok {
+		panic(fmt.Sprintf("proposal type %s has already been registered", ptype))
+	}
+	proposalTypes[ptype] = t
+}
+
+func newProposalContent(ptype string) ProposalContent {
+	t, ok := proposalTypes[ptype]
+	if !ok {
+		return nil
+	}
+	return t.NewContent()
+}
+
+// ProposalThresholds returns the quorum and the pass threshold of the proposal type
+func ProposalThresholds(ptype string) (quorum, threshold sdk.Rat) {
+	t, ok := proposalTypes[ptype]
+	if !ok {
+		return
+	}
+	return t.Thresholds(utils.GetParams())
+}
+
+func loadProposalContent(tx *sql.Tx, ptype, pid string) ProposalContent {
+	content := newProposalContent(ptype)
+	if content == nil || !content.Load(tx, pid) {
+		return nil
+	}
+	return content
+}
+
+// checkPropose checks a proposal submitted by a validator,
+// the proposer has to afford both the gas fee of the proposal type and the deposit
+func checkPropose(ctx types.Context, sender common.Address, r *proposeRequest) error {
+	t, ok := proposalTypes[r.Type]
+	if !ok {
+		return ErrInvalidParameter()
+	}
+
+	if !isValidatorOwner(stake.GetCandidates().Validators(), sender) {
+		return ErrInvalidValidator()
+	}
+
+	if r.ExpireTimestamp != nil && r.ExpireBlockHeight != nil {
+		return ErrExceedsExpiration()
+	}
+
+	if r.ExpireTimestamp != nil && ctx.BlockTime() > *r.ExpireTimestamp {
+		return ErrInvalidExpireTimestamp()
+	}
+
+	if r.ExpireBlockHeight != nil && ctx.BlockHeight() >= *r.ExpireBlockHeight {
+		return ErrInvalidExpireBlockHeight()
+	}
+
+	if r.ActivationBlockHeight != nil && ctx.BlockHeight() >= *r.ActivationBlockHeight {
+		return ErrInvalidTimelock()
+	}
+
+	if err := r.Content.Validate(ctx); err != nil {
+		return err
+	}
+
+	_, err := checkProposalFee(ctx.EthappState(), sender, t.Gas(utils.GetParams()))
+	return err
+}
+
+// deliverPropose creates the proposal checked by checkPropose, identified by the hash of the tx.
+// The gas fee is charged and the deposit is escrowed, then the proposal is pending until it is decided or expires.
+func deliverPropose(ctx types.Context, sender common.Address, r *proposeRequest, hash []byte) (res sdk.DeliverResult, err error) {
+	res.GasFee = big.NewInt(0)
+	state := ctx.EthappState()
+	params := utils.GetParams()
+
+	gasUsed := proposalTypes[r.Type].Gas(params)
+	gasFee, err := checkGasFee(state, sender, gasUsed)
+	if err != nil {
+		return res, err
+	}
+	res.GasFee = gasFee
+	res.GasUsed = int64(gasUsed)
+	// transfer gasFee
+	state.SubBalance(sender, gasFee)
+	state.AddBalance(utils.HoldAccount, gasFee)
+
+	expireBlockHeight := ctx.BlockHeight() + int64(params.ProposalExpirePeriod)
+	var expireTimestamp int64
+	if r.ExpireTimestamp != nil {
+		expireTimestamp = *r.ExpireTimestamp
+		expireBlockHeight = 0
+	} else if r.ExpireBlockHeight != nil {
+		expireBlockHeight = *r.ExpireBlockHeight
+	}
+
+	hashJson, _ := json.Marshal(hash)
+	p := NewProposal(
+		string(hashJson[1:len(hashJson)-1]),
+		r.Type,
+		&sender,
+		ctx.BlockHeight(),
+		r.Content,
+		expireTimestamp,
+		expireBlockHeight,
+	)
+	if _, ok := r.Content.(TimelockedContent); ok {
+		p.ExecutionDelay, p.ActivationBlockHeight = resolveTimelock(r.ExecutionDelay, r.ActivationBlockHeight)
+	}
+	p.Deposit = escrowDeposit(state, sender)
+	SaveProposal(p)
+
+	if _, ok := r.Content.(CheckAheadContent); ok {
+		utils.PendingProposal.Add(p.Id, p.ExpireTimestamp, p.ExpireBlockHeight-1)
+	} else {
+		utils.PendingProposal.Add(p.Id, p.ExpireTimestamp, p.ExpireBlockHeight)
+	}
+
+	if c, ok := r.Content.(SubmitContent); ok {
+		c.OnSubmit(&ProposalContext{state, ctx.BlockHeight(), nil}, p)
+	}
+
+	res.Data = hash
+	return
+}
+
+// NewProposal returns a pending proposal of the type, with the content of the type
+func NewProposal(id, ptype string, proposer *common.Address, blockHeight int64, content ProposalContent, expireTimestamp, expireBlockHeight int64) *Proposal {
+	return &Proposal{
+		id,
+		ptype,
+		proposer,
+		blockHeight,
+		expireTimestamp,
+		expireBlockHeight,
+		"",
+		"",
+		0,
+		"0",
+		0,
+		0,
+		0,
+		content,
+	}
+}
+
+// ResolveProposal applies the result of CheckProposal to a pending proposal.
+// It is called when a vote decides the proposal, and with expired set when the proposal reaches its expiration,
+// an undecided proposal is only closed in the latter case.
+func ResolveProposal(ctx *ProposalContext, p *Proposal, checkResult string, expired bool) {
+	switch checkResult {
+	case "approved":
+		if _, ok := p.Content.(DeferredContent); ok && !expired {
+			// executed at the expiration
+			UpdateProposalResult(p.Id, "Approved", "", ctx.BlockHeight)
+			return
+		}
+		// executed at the execute block height if timelocked
+		if QueueProposal(p, ctx.BlockHeight) {
+			return
+		}
+		if _, ok := p.Content.(CommitContent); ok && ctx.EVM == nil {
+			queueProposal(p, ctx.BlockHeight, ctx.BlockHeight)
+			return
+		}
+		UpdateProposalResult(p.Id, "Approved", p.Content.Execute(ctx, p), ctx.BlockHeight)
+	case "rejected":
+		p.Content.OnReject(ctx, p)
+		UpdateProposalResult(p.Id, "Rejected", "", ctx.BlockHeight)
+	default:
+		if !expired {
+			return
+		}
+		p.Content.OnExpire(ctx, p)
+		UpdateProposalResult(p.Id, "Expired", "", ctx.BlockHeight)
+	}
+	utils.PendingProposal.Del(p.Id)
+}
+
+// LoadPendingProposals restores the pending proposals by their expiration when the node starts,
+// and resumes the downloads of the libraries of the pending deploy_libeni proposals
+func LoadPendingProposals() {
+	pendingProposals := GetPendingProposals()
+	if len(pendingProposals) == 0 {
+		return
+	}
+
+	proposalsTS := make(map[string]int64)
+	proposalsBH := make(map[string]int64)
+	for _, pp := range pendingProposals {
+		if pp.ExpireTimestamp > 0 {
+			proposalsTS[pp.Id] = pp.ExpireTimestamp
+		} else {
+			proposalsBH[pp.Id] = pp.ExpireBlockHeight
+		}
+
+		if pp.Type == DEPLOY_LIBENI_PROPOSAL {
+			dp := GetProposalById(pp.Id)
+			if dp.Content.(*DeployLibEniContent).Status != "ready" {
+				DownloadLibEni(dp)
+			}
+		}
+	}
+	utils.PendingProposal.BatchAddTS(proposalsTS)
+	utils.PendingProposal.BatchAddBH(proposalsBH)
+}
+
+// ProcessPendingProposals resolves the pending proposals which reach their expiration at the block,
+// and executes the queued ones reaching their execute block height. It is called at the commit of the block.
+func ProcessPendingProposals(state *ethState.StateDB, evm ContractCaller, blockTime, blockHeight int64) {
+	ctx := &ProposalContext{state, blockHeight, evm}
+	for _, pid := range utils.PendingProposal.ReachMin(blockTime, blockHeight) {
+		p := GetProposalById(pid)
+		if p == nil {
+			utils.PendingProposal.Del(pid)
+			continue
+		}
+
+		switch p.Result {
+		case "":
+			checkResult := CheckProposal(pid, nil, blockHeight)
+			SettleDeposit(state, p, checkResult)
+			ResolveProposal(ctx, p, checkResult, true)
+		case "Queued":
+			UpdateProposalResult(p.Id, "Approved", p.Content.Execute(ctx, p), blockHeight)
+			utils.PendingProposal.Del(pid)
+		case "Approved":
+			// deferred proposals approved before their expiration
+			p.Content.Execute(ctx, p)
+			utils.PendingProposal.Del(pid)
+		default:
+			utils.PendingProposal.Del(pid)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package governance
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+const CHANGE_PARAM_PROPOSAL = "change_param"
+
+func init() {
+	RegisterProposalType(CHANGE_PARAM_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &ChangeParamContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.ChangeParamsProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.ChangeParamsProposalQuorum, params.ChangeParamsProposalThreshold
+		},
+	})
+}
+
+// ParamChange is a name/value pair of a change param proposal
+type ParamChange struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ChangeParamContent changes several params together, all of them or none
+type ChangeParamContent struct {
+	// name and value are kept for the proposals changing a single param
+	Name   string        `json:"name"`
+	Value  string        `json:"value"`
+	Params []ParamChange `json:"params"`
+	Reason string        `json:"reason"`
+}
+
+func (c *ChangeParamContent) Timelocked() {}
+
+func NewChangeParamContent(params []ParamChange, reason string) *ChangeParamContent {
+	c := &ChangeParamContent{Params: params, Reason: reason}
+	if len(params) == 1 {
+		c.Name, c.Value = params[0].Name, params[0].Value
+	}
+	return c
+}
+
+// Validate checks the values of the params, and that the params stay consistent once all of them are changed
+func (c *ChangeParamContent) Validate(ctx types.Context) error {
+	changed := *utils.GetParams()
+	for _, pc := range c.Params {
+		if !utils.CheckParamType(pc.Name, pc.Value) || !changed.Set(pc.Name, pc.Value) {
+			return ErrInvalidParameter()
+		}
+	}
+	if err := changed.Validate(); err != nil {
+		return ErrInconsistentParams(err)
+	}
+	return nil
+}
+
+// Execute sets all the params of the approved proposal, or none of them
+// if any is invalid, in which case the reason is returned
+func (c *ChangeParamContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	for _, pc := range c.Params {
+		if !utils.CheckParamType(pc.Name, pc.Value) {
+			return "Invalid value for param " + pc.Name + ", no param is changed"
+		}
+	}
+
+	backup := *utils.GetParams()
+	for _, pc := range c.Params {
+		if !utils.SetParam(pc.Name, pc.Value) {
+			*utils.GetParams() = backup
+			return "Failed to set param " + pc.Name + ", no param is changed"
+		}
+	}
+	if err := utils.GetParams().Validate(); err != nil {
+		*utils.GetParams() = backup
+		return "Invalid params, " + err.Error() + ", no param is changed"
+	}
+	return ""
+}
+
+func (c *ChangeParamContent) OnReject(ctx *ProposalContext, p *Proposal) {}
+
+func (c *ChangeParamContent) OnExpire(ctx *ProposalContext, p *Proposal) {}
+
+func (c *ChangeParamContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_change_param_detail(proposal_id, param_name, param_value,  reason, params) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	params, _ := json.Marshal(c.Params)
+	_, err = stmt.Exec(pid, c.Name, c.Value, c.Reason, string(params))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *ChangeParamContent) Load(tx *sql.Tx, pid string) bool {
+	var params string
+	err := tx.QueryRow("select param_name, param_value, reason, params from governance_change_param_detail where proposal_id = ?", pid).Scan(&c.Name, &c.Value, &c.Reason, &params)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+
+	c.Params = decodeParamChanges(c.Name, c.Value, params)
+	return true
+}
+
+// decodeParamChanges restores the params of a change param proposal,
+// the proposals created before the params column only changed a single param
+func decodeParamChanges(name, value, params string) (pcs []ParamChange) {
+	if params == "" {
+		return []ParamChange{{name, value}}
+	}
+	if err := json.Unmarshal([]byte(params), &pcs); err != nil {
+		panic(err)
+	}
+	return
+}
@@ -0,0 +1,78 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+func TestChangeParamValidate(t *testing.T) {
+	utils.SetParams(utils.DefaultParams())
+	defer utils.SetParams(utils.DefaultParams())
+
+	a, b := "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc", "0x84bf1d4b5b53c1b7e2d4b4bfa2e0f8f1c4fcd7a3"
+	validate := func(params ...ParamChange) error {
+		return NewChangeParamContent(params, "test").Validate(types.Context{})
+	}
+
+	assert.NoError(t, validate(ParamChange{"gas_price", "1"}))
+	assert.Error(t, validate(ParamChange{"gas_price", "-1"}))
+	assert.Error(t, validate(ParamChange{"unknown_param", "1"}))
+
+	// the threshold can't exceed the members of the committee, whichever is changed
+	assert.Error(t, validate(ParamChange{"foundation_committee_threshold", "2"}))
+	assert.NoError(t, validate(ParamChange{"foundation_committee", a + "," + b}, ParamChange{"foundation_committee_threshold", "2"}))
+	assert.NoError(t, validate(ParamChange{"foundation_committee_threshold", "2"}, ParamChange{"foundation_committee", a + "," + b}))
+
+	// the params in force are left unchanged
+	assert.Equal(t, 1, utils.GetParams().FoundationCommitteeThreshold)
+	assert.Equal(t, a, utils.GetParams().FoundationCommittee)
+}
+
+func TestChangeParamExecute(t *testing.T) {
+	utils.SetParams(utils.DefaultParams())
+	defer utils.SetParams(utils.DefaultParams())
+
+	execute := func(params ...ParamChange) string {
+		return NewChangeParamContent(params, "test").Execute(&ProposalContext{}, &Proposal{})
+	}
+
+	assert.Equal(t, "", execute(ParamChange{"gas_price", "3000000000"}, ParamChange{"low_price_tx_gas_limit", "50000"}))
+	assert.Equal(t, uint64(3000000000), utils.GetParams().GasPrice)
+	assert.Equal(t, uint64(50000), utils.GetParams().LowPriceTxGasLimit)
+
+	// a single invalid value leaves all the params unchanged
+	assert.NotEqual(t, "", execute(ParamChange{"gas_price", "4000000000"}, ParamChange{"low_price_tx_gas_limit", "100"}))
+	assert.Equal(t, uint64(3000000000), utils.GetParams().GasPrice)
+	assert.Equal(t, uint64(50000), utils.GetParams().LowPriceTxGasLimit)
+}
+
+func TestChangeParamLoad(t *testing.T) {
+	defer setupTestDb(t)()
+
+	tx, err := getDb().Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	multi := NewChangeParamContent([]ParamChange{{"gas_price", "3000000000"}, {"low_price_tx_gas_limit", "50000"}}, "together")
+	multi.Save(tx, "multi")
+	// a proposal stored before the params column was added
+	if _, err = tx.Exec("insert into governance_change_param_detail(proposal_id, param_name, param_value, reason) values('legacy', 'gas_price', '2000000000', 'alone')"); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := &ChangeParamContent{}
+	assert.True(t, loaded.Load(tx, "multi"))
+	assert.Equal(t, multi, loaded)
+
+	legacy := &ChangeParamContent{}
+	assert.True(t, legacy.Load(tx, "legacy"))
+	assert.Equal(t, []ParamChange{{"gas_price", "2000000000"}}, legacy.Params)
+
+	assert.False(t, (&ChangeParamContent{}).Load(tx, "missing"))
+}
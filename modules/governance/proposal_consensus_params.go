@@ -0,0 +1,140 @@
+package governance
+
+import (
+	"database/sql"
+	"math"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+const CONSENSUS_PARAMS_PROPOSAL = "consensus_params"
+
+func init() {
+	RegisterProposalType(CONSENSUS_PARAMS_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &ConsensusParamsContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.ConsensusParamsProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.ConsensusParamsProposalQuorum, params.ConsensusParamsProposalThreshold
+		},
+	})
+}
+
+// ConsensusParamsContent changes the tendermint consensus params, the params left nil are unchanged.
+// The update is returned by the EndBlock of the block following the execution.
+type ConsensusParamsContent struct {
+	BlockMaxBytes      *int64 `json:"block_max_bytes,omitempty"`
+	BlockMaxTxs        *int64 `json:"block_max_txs,omitempty"`
+	BlockMaxGas        *int64 `json:"block_max_gas,omitempty"`
+	TxMaxBytes         *int64 `json:"tx_max_bytes,omitempty"`
+	TxMaxGas           *int64 `json:"tx_max_gas,omitempty"`
+	BlockPartSizeBytes *int64 `json:"block_part_size_bytes,omitempty"`
+	Reason             string `json:"reason"`
+}
+
+func (c *ConsensusParamsContent) Timelocked() {}
+
+// apply returns the consensus params with the changes of the content, or an error if the result is invalid
+func (c *ConsensusParamsContent) apply(cp tmtypes.ConsensusParams) (tmtypes.ConsensusParams, error) {
+	// the sizes are int32 in the ABCI
+	for _, v := range []*int64{c.BlockMaxBytes, c.BlockMaxTxs, c.TxMaxBytes, c.BlockPartSizeBytes} {
+		if v != nil && (*v < math.MinInt32 || *v > math.MaxInt32) {
+			return cp, errInvalidParameter
+		}
+	}
+
+	if c.BlockMaxBytes != nil {
+		cp.BlockSize.MaxBytes = int(*c.BlockMaxBytes)
+	}
+	if c.BlockMaxTxs != nil {
+		cp.BlockSize.MaxTxs = int(*c.BlockMaxTxs)
+	}
+	if c.BlockMaxGas != nil {
+		cp.BlockSize.MaxGas = *c.BlockMaxGas
+	}
+	if c.TxMaxBytes != nil {
+		cp.TxSize.MaxBytes = int(*c.TxMaxBytes)
+	}
+	if c.TxMaxGas != nil {
+		cp.TxSize.MaxGas = *c.TxMaxGas
+	}
+	if c.BlockPartSizeBytes != nil {
+		cp.BlockGossip.BlockPartSizeBytes = int(*c.BlockPartSizeBytes)
+	}
+	return cp, cp.Validate()
+}
+
+func (c *ConsensusParamsContent) Validate(ctx types.Context) error {
+	if c.BlockMaxBytes == nil && c.BlockMaxTxs == nil && c.BlockMaxGas == nil &&
+		c.TxMaxBytes == nil && c.TxMaxGas == nil && c.BlockPartSizeBytes == nil {
+		return ErrInsufficientParameters()
+	}
+	if _, err := c.apply(GetConsensusParams()); err != nil {
+		return ErrInvalidParameter()
+	}
+	return nil
+}
+
+// Execute schedules the update at the next block, it is validated again against the params in effect at that block
+func (c *ConsensusParamsContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	if _, err := c.apply(GetConsensusParams()); err != nil {
+		return "Invalid consensus params, " + err.Error()
+	}
+	ScheduleConsensusParams(p.Id, ctx.BlockHeight+1)
+	return ""
+}
+
+func (c *ConsensusParamsContent) OnReject(ctx *ProposalContext, p *Proposal) {}
+
+func (c *ConsensusParamsContent) OnExpire(ctx *ProposalContext, p *Proposal) {}
+
+func (c *ConsensusParamsContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_consensus_params_detail(proposal_id, block_max_bytes, block_max_txs, block_max_gas, tx_max_bytes, tx_max_gas, block_part_size_bytes, reason) values(?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pid, toNullInt64(c.BlockMaxBytes), toNullInt64(c.BlockMaxTxs), toNullInt64(c.BlockMaxGas),
+		toNullInt64(c.TxMaxBytes), toNullInt64(c.TxMaxGas), toNullInt64(c.BlockPartSizeBytes), c.Reason)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *ConsensusParamsContent) Load(tx *sql.Tx, pid string) bool {
+	var blockMaxBytes, blockMaxTxs, blockMaxGas, txMaxBytes, txMaxGas, blockPartSizeBytes sql.NullInt64
+	err := tx.QueryRow("select block_max_bytes, block_max_txs, block_max_gas, tx_max_bytes, tx_max_gas, block_part_size_bytes, reason from governance_consensus_params_detail where proposal_id = ?", pid).Scan(
+		&blockMaxBytes, &blockMaxTxs, &blockMaxGas, &txMaxBytes, &txMaxGas, &blockPartSizeBytes, &c.Reason)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+
+	c.BlockMaxBytes = fromNullInt64(blockMaxBytes)
+	c.BlockMaxTxs = fromNullInt64(blockMaxTxs)
+	c.BlockMaxGas = fromNullInt64(blockMaxGas)
+	c.TxMaxBytes = fromNullInt64(txMaxBytes)
+	c.TxMaxGas = fromNullInt64(txMaxGas)
+	c.BlockPartSizeBytes = fromNullInt64(blockPartSizeBytes)
+	return true
+}
+
+func toNullInt64(v *int64) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *v, Valid: true}
+}
+
+func fromNullInt64(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int64
+}
@@ -0,0 +1,54 @@
+package governance
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestConsensusParamsApply(t *testing.T) {
+	assert := assert.New(t)
+
+	int64p := func(v int64) *int64 { return &v }
+	defaults := *tmtypes.DefaultConsensusParams()
+
+	cases := []struct {
+		name    string
+		content *ConsensusParamsContent
+		// changes the default params into the expected ones, nil if the content is invalid
+		expected func(cp *tmtypes.ConsensusParams)
+	}{
+		{"nothing changed", &ConsensusParamsContent{}, func(cp *tmtypes.ConsensusParams) {}},
+		{"block size", &ConsensusParamsContent{BlockMaxBytes: int64p(1 << 20), BlockMaxTxs: int64p(5000)}, func(cp *tmtypes.ConsensusParams) {
+			cp.BlockSize.MaxBytes = 1 << 20
+			cp.BlockSize.MaxTxs = 5000
+		}},
+		{"gas limits", &ConsensusParamsContent{BlockMaxGas: int64p(math.MaxInt32 + 1), TxMaxGas: int64p(1e6)}, func(cp *tmtypes.ConsensusParams) {
+			cp.BlockSize.MaxGas = math.MaxInt32 + 1
+			cp.TxSize.MaxGas = 1e6
+		}},
+		{"tx size and block parts", &ConsensusParamsContent{TxMaxBytes: int64p(1024), BlockPartSizeBytes: int64p(32768)}, func(cp *tmtypes.ConsensusParams) {
+			cp.TxSize.MaxBytes = 1024
+			cp.BlockGossip.BlockPartSizeBytes = 32768
+		}},
+		{"size beyond int32", &ConsensusParamsContent{BlockMaxBytes: int64p(math.MaxInt32 + 1)}, nil},
+		{"size below int32", &ConsensusParamsContent{TxMaxBytes: int64p(math.MinInt32 - 1)}, nil},
+		{"empty blocks", &ConsensusParamsContent{BlockMaxBytes: int64p(0)}, nil},
+		{"blocks too large", &ConsensusParamsContent{BlockMaxBytes: int64p(200 << 20)}, nil},
+		{"empty block parts", &ConsensusParamsContent{BlockPartSizeBytes: int64p(0)}, nil},
+	}
+
+	for _, c := range cases {
+		cp, err := c.content.apply(defaults)
+		if c.expected == nil {
+			assert.Error(err, c.name)
+			continue
+		}
+		expected := defaults
+		c.expected(&expected)
+		assert.NoError(err, c.name)
+		assert.Equal(expected, cp, c.name)
+	}
+}
@@ -0,0 +1,222 @@
+package governance
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/vangjvn/devchain/commons"
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+const CONTRACT_CALL_PROPOSAL = "contract_call"
+
+// selector of Error(string), the revert reason encoded by solidity
+var revertSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+func init() {
+	RegisterProposalType(CONTRACT_CALL_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &ContractCallContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.ContractCallProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.ContractCallProposalQuorum, params.ContractCallProposalThreshold
+		},
+	})
+}
+
+// ContractCallContent calls a contract from the governance account, the value is paid from its balance
+type ContractCallContent struct {
+	To     common.Address `json:"to"`
+	Value  string         `json:"value"`
+	Data   string         `json:"data"`
+	Gas    uint64         `json:"gas"`
+	Reason string         `json:"reason"`
+}
+
+// ContractCallReceipt is the outcome of the call made by an approved contract_call proposal
+type ContractCallReceipt struct {
+	ProposalId   string          `json:"proposal_id"`
+	Status       uint64          `json:"status"`
+	GasUsed      uint64          `json:"gas_used"`
+	ReturnData   string          `json:"return_data"`
+	Logs         []*ethTypes.Log `json:"logs"`
+	RevertReason string          `json:"revert_reason"`
+	Error        string          `json:"error"`
+	BlockHeight  int64           `json:"block_height"`
+}
+
+func (c *ContractCallContent) ExecuteAtCommit() {}
+
+func (c *ContractCallContent) Timelocked() {}
+
+func (c *ContractCallContent) value() *big.Int {
+	value, ok := new(big.Int).SetString(c.Value, 10)
+	if !ok {
+		return nil
+	}
+	return value
+}
+
+func (c *ContractCallContent) data() ([]byte, error) {
+	if c.Data == "" {
+		return nil, nil
+	}
+	return hexutil.Decode(c.Data)
+}
+
+func (c *ContractCallContent) Validate(ctx types.Context) error {
+	value := c.value()
+	if value == nil || value.Sign() < 0 {
+		return ErrInvalidParameter()
+	}
+	if _, err := c.data(); err != nil {
+		return ErrInvalidParameter()
+	}
+	if c.Gas == 0 || c.Gas > utils.GetParams().ContractCallProposalMaxGas {
+		return ErrInvalidCallGas()
+	}
+
+	balance, err := commons.GetBalance(ctx.EthappState(), utils.GovAccount)
+	if err != nil {
+		return ErrInvalidParameter()
+	}
+	if balance.Cmp(value) < 0 {
+		return ErrInsufficientBalance()
+	}
+	return nil
+}
+
+// Execute makes the call against the state at the commit of the block,
+// the receipt is saved and the revert reason or the error is returned as the result message
+func (c *ContractCallContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	receipt := &ContractCallReceipt{ProposalId: p.Id, BlockHeight: ctx.BlockHeight}
+	defer saveContractCallReceipt(receipt)
+
+	value := c.value()
+	data, err := c.data()
+	switch {
+	case value == nil || err != nil:
+		receipt.Error = errInvalidParameter.Error()
+		return receipt.Error
+	case ctx.State.GetBalance(utils.GovAccount).Cmp(value) < 0:
+		// the balance may have been spent since the submission
+		receipt.Error = errInsufficientBalance.Error()
+		return receipt.Error
+	}
+
+	ret, logs, gasUsed, err := ctx.EVM.Call(utils.GovAccount, c.To, value, data, c.Gas, crypto.Keccak256Hash([]byte(p.Id)))
+	receipt.GasUsed = gasUsed
+	receipt.ReturnData = hexutil.Encode(ret)
+	receipt.Logs = logs
+	if err != nil {
+		receipt.Error = err.Error()
+		receipt.RevertReason = revertReason(ret)
+		if receipt.RevertReason != "" {
+			return receipt.RevertReason
+		}
+		return receipt.Error
+	}
+	receipt.Status = ethTypes.ReceiptStatusSuccessful
+	return ""
+}
+
+func (c *ContractCallContent) OnReject(ctx *ProposalContext, p *Proposal) {}
+
+func (c *ContractCallContent) OnExpire(ctx *ProposalContext, p *Proposal) {}
+
+func (c *ContractCallContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_contract_call_detail(proposal_id, to_address, value, data, gas, reason) values(?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pid, c.To.String(), c.Value, c.Data, c.Gas, c.Reason)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *ContractCallContent) Load(tx *sql.Tx, pid string) bool {
+	var toAddr string
+	err := tx.QueryRow("select to_address, value, data, gas, reason from governance_contract_call_detail where proposal_id = ?", pid).Scan(&toAddr, &c.Value, &c.Data, &c.Gas, &c.Reason)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+
+	c.To = common.HexToAddress(toAddr)
+	return true
+}
+
+// revertReason decodes the reason of a failed require or revert, it returns "" if ret is not an Error(string)
+func revertReason(ret []byte) string {
+	if len(ret) < 4+64 || !bytes.Equal(ret[:4], revertSelector) {
+		return ""
+	}
+	ret = ret[4:]
+	// the bounds are compared without adding to the decoded values, which could overflow
+	offset := new(big.Int).SetBytes(ret[:32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(ret))-32 {
+		return ""
+	}
+	start := offset.Uint64()
+	size := new(big.Int).SetBytes(ret[start : start+32])
+	if !size.IsUint64() || size.Uint64() > uint64(len(ret))-start-32 {
+		return ""
+	}
+	return string(ret[start+32 : start+32+size.Uint64()])
+}
+
+func saveContractCallReceipt(r *ContractCallReceipt) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("insert into governance_contract_call_receipt(proposal_id, status, gas_used, return_data, logs, revert_reason, error, block_height) values(?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	logs, _ := json.Marshal(r.Logs)
+	_, err = stmt.Exec(r.ProposalId, r.Status, r.GasUsed, r.ReturnData, string(logs), r.RevertReason, r.Error, r.BlockHeight)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+// QueryContractCallReceipt returns the receipt of an executed contract_call proposal, or nil if it hasn't been executed
+func QueryContractCallReceipt(pid string) *ContractCallReceipt {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	var logs string
+	r := &ContractCallReceipt{ProposalId: pid}
+	err = tx.QueryRow("select status, gas_used, return_data, logs, revert_reason, error, block_height from governance_contract_call_receipt where proposal_id = ?", pid).Scan(&r.Status, &r.GasUsed, &r.ReturnData, &logs, &r.RevertReason, &r.Error, &r.BlockHeight)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		panic(err)
+	}
+
+	if err = json.Unmarshal([]byte(logs), &r.Logs); err != nil {
+		panic(err)
+	}
+	return r
+}
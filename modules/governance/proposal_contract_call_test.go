@@ -0,0 +1,44 @@
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevertReason(t *testing.T) {
+	assert := assert.New(t)
+
+	word := func(v *big.Int) []byte { return common.LeftPadBytes(v.Bytes(), 32) }
+	encode := func(offset, size *big.Int, data string) []byte {
+		ret := append([]byte{}, revertSelector...)
+		ret = append(ret, word(offset)...)
+		ret = append(ret, word(size)...)
+		return append(ret, common.RightPadBytes([]byte(data), (len(data)+31)/32*32)...)
+	}
+	huge := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(16))
+
+	cases := []struct {
+		name     string
+		ret      []byte
+		expected string
+	}{
+		{"reason", encode(big.NewInt(32), big.NewInt(14), "not authorized"), "not authorized"},
+		{"reason of a full word", encode(big.NewInt(32), big.NewInt(32), "0123456789abcdef0123456789abcdef"), "0123456789abcdef0123456789abcdef"},
+		{"empty reason", encode(big.NewInt(32), big.NewInt(0), ""), ""},
+		{"no return data", nil, ""},
+		{"too short", encode(big.NewInt(32), big.NewInt(0), "")[:4+63], ""},
+		{"other selector", append([]byte{0x4e, 0x48, 0x7b, 0x71}, encode(big.NewInt(32), big.NewInt(2), "no")[4:]...), ""},
+		{"size beyond the data", encode(big.NewInt(32), big.NewInt(33), "not authorized"), ""},
+		{"offset beyond the data", encode(big.NewInt(64), big.NewInt(2), "no"), ""},
+		{"offset overflowing", encode(huge, big.NewInt(2), "no"), ""},
+		{"size overflowing", encode(big.NewInt(32), huge, "no"), ""},
+		{"offset beyond uint64", encode(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(2), "no"), ""},
+	}
+
+	for _, c := range cases {
+		assert.Equal(c.expected, revertReason(c.ret), c.name)
+	}
+}
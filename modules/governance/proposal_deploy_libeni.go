@@ -0,0 +1,223 @@
+package governance
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/vm/eni"
+
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+const DEPLOY_LIBENI_PROPOSAL = "deploy_libeni"
+
+func init() {
+	RegisterProposalType(DEPLOY_LIBENI_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &DeployLibEniContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.DeployLibEniProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.DeployLibEniProposalQuorum, params.DeployLibEniProposalThreshold
+		},
+	})
+}
+
+// Release is a version of a library or of the program, the download urls,
+// the SHA-256 digests and their ed25519 signatures per platform are encoded in json.
+// Legacy releases are identified by their md5 checksums instead of the digests.
+type Release struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	FileUrl   string `json:"fileurl"`
+	Md5       string `json:"md5"`
+	Sha256    string `json:"sha256"`
+	Signature string `json:"signature"`
+}
+
+// releaseContent is implemented by the contents embedding a Release
+type releaseContent interface {
+	release() *Release
+}
+
+func (r *Release) release() *Release {
+	return r
+}
+
+func (r *Release) legacy() bool {
+	return r.Sha256 == ""
+}
+
+// validate checks a new release, md5 is only accepted for the legacy releases submitted before
+func (r *Release) validate() error {
+	var fileurlJson map[string][]string
+	if err := json.Unmarshal([]byte(r.FileUrl), &fileurlJson); err != nil {
+		return ErrInvalidFileurlJson()
+	}
+	if _, ok := fileurlJson[utils.GOOSDIST]; !ok {
+		return ErrNoFileurl()
+	}
+
+	if r.legacy() {
+		return ErrNoSha256()
+	}
+	var sha256Json, signatureJson map[string]string
+	if err := json.Unmarshal([]byte(r.Sha256), &sha256Json); err != nil {
+		return ErrInvalidSha256Json()
+	}
+	if _, ok := sha256Json[utils.GOOSDIST]; !ok {
+		return ErrNoSha256()
+	}
+	// the digest of every platform has to be signed, unless no publisher key is configured
+	keys := utils.GetParams().OtaPublisherKeyList()
+	if err := json.Unmarshal([]byte(r.Signature), &signatureJson); err != nil && len(keys) > 0 {
+		return ErrInvalidReleaseSignature()
+	}
+	for platform, digest := range sha256Json {
+		checksum := types.ArtifactChecksum{Sha256: digest, Signature: signatureJson[platform]}
+		if err := checksum.VerifySignature(keys); err != nil {
+			return ErrInvalidReleaseSignature()
+		}
+	}
+	return nil
+}
+
+// checksum returns the checksum of the release for the platform of the node, or nil if there is none
+func (r *Release) checksum() *types.ArtifactChecksum {
+	if r.legacy() {
+		var md5Json map[string]string
+		if err := json.Unmarshal([]byte(r.Md5), &md5Json); err != nil {
+			return nil
+		}
+		md5, ok := md5Json[utils.GOOSDIST]
+		if !ok {
+			return nil
+		}
+		return &types.ArtifactChecksum{MD5: md5}
+	}
+
+	var sha256Json, signatureJson map[string]string
+	if err := json.Unmarshal([]byte(r.Sha256), &sha256Json); err != nil {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(r.Signature), &signatureJson); err != nil {
+		return nil
+	}
+	digest, ok := sha256Json[utils.GOOSDIST]
+	if !ok {
+		return nil
+	}
+	return &types.ArtifactChecksum{Sha256: digest, Signature: signatureJson[utils.GOOSDIST]}
+}
+
+// otaInfo returns the download info for the platform of the node, or nil if there is none.
+// The checksum is the md5 of a legacy release, it is left empty otherwise.
+func (r *Release) otaInfo() *eni.OTAInfo {
+	var fileurlJson map[string][]string
+	if err := json.Unmarshal([]byte(r.FileUrl), &fileurlJson); err != nil {
+		return nil
+	}
+
+	fileurl, ok := fileurlJson[utils.GOOSDIST]
+	if !ok {
+		return nil
+	}
+
+	checksum := r.checksum()
+	if checksum == nil {
+		return nil
+	}
+
+	return &eni.OTAInfo{
+		r.Name,
+		r.Version,
+		fileurl,
+		checksum.MD5,
+	}
+}
+
+// DeployLibEniContent deploys a library callable by the contracts through ENI,
+// the library is downloaded once the proposal is submitted, and registered at the expiration if approved
+type DeployLibEniContent struct {
+	Release
+	Reason string `json:"reason"`
+	// download status, updated aside of the proposal
+	Status string `json:"status"`
+}
+
+func (c *DeployLibEniContent) ExecuteAtExpiration() {}
+
+// OnSubmit starts the download of the library, for it to be ready when the proposal is approved
+func (c *DeployLibEniContent) OnSubmit(ctx *ProposalContext, p *Proposal) {
+	DownloadLibEni(p)
+}
+
+func (c *DeployLibEniContent) Validate(ctx types.Context) error {
+	if strings.Trim(c.Name, " ") == "" || strings.Trim(c.Version, " ") == "" {
+		return ErrInsufficientParameters()
+	}
+
+	otaInfo := eni.OTAInfo{
+		LibName: c.Name,
+		Version: c.Version,
+	}
+	if valid, _ := OTAInstance.IsValidNewLib(otaInfo); !valid {
+		return ErrInvalidNewLib()
+	}
+
+	if HasUndeployedProposal(c.Name) {
+		return ErrOngoingLibFound()
+	}
+
+	return c.Release.validate()
+}
+
+// Execute registers the downloaded library, or has it registered once downloaded.
+// The outcome depends on the downloads of the node, so it is only recorded as the status of the library
+// and in the download progress, the result message is always empty to keep the proposal hash the same on all nodes.
+func (c *DeployLibEniContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	if c.Status != "ready" {
+		CancelDownload(p, true)
+	} else if err := RegisterLibEni(p); err != nil {
+		downloads.setError(p.Id, err)
+		UpdateDeployLibEniStatus(p.Id, "collapsed")
+	} else {
+		UpdateDeployLibEniStatus(p.Id, "deployed")
+	}
+	return ""
+}
+
+func (c *DeployLibEniContent) OnReject(ctx *ProposalContext, p *Proposal) {
+	if c.Status != "ready" {
+		CancelDownload(p, false)
+	}
+}
+
+func (c *DeployLibEniContent) OnExpire(ctx *ProposalContext, p *Proposal) {
+	c.OnReject(ctx, p)
+}
+
+func (c *DeployLibEniContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_deploy_libeni_detail(proposal_id, name, version, fileurl, md5, sha256, signature, reason, status) values(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pid, c.Name, c.Version, c.FileUrl, c.Md5, c.Sha256, c.Signature, c.Reason, c.Status)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *DeployLibEniContent) Load(tx *sql.Tx, pid string) bool {
+	err := tx.QueryRow("select name, version, fileurl, md5, sha256, signature, reason, status from governance_deploy_libeni_detail where proposal_id = ?", pid).Scan(&c.Name, &c.Version, &c.FileUrl, &c.Md5, &c.Sha256, &c.Signature, &c.Reason, &c.Status)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package governance
+
+import (
+	"database/sql"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethState "github.com/ethereum/go-ethereum/core/state"
+
+	"github.com/vangjvn/devchain/commons"
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+const GRANT_PROPOSAL = "grant"
+const CANCEL_GRANT_PROPOSAL = "cancel_grant"
+
+func init() {
+	RegisterProposalType(GRANT_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &GrantContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.GrantProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.GrantProposalQuorum, params.GrantProposalThreshold
+		},
+	})
+	RegisterProposalType(CANCEL_GRANT_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &CancelGrantContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.CancelGrantProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.CancelGrantProposalQuorum, params.CancelGrantProposalThreshold
+		},
+	})
+}
+
+// GrantContent streams an amount to the recipient over time. The whole amount is escrowed
+// in the governance hold account when the proposal is submitted, once approved it is
+// released in tranches from EndBlock, see ReleaseGrants.
+type GrantContent struct {
+	From     common.Address `json:"from"`
+	To       common.Address `json:"to"`
+	Amount   string         `json:"amount"`
+	Tranches int64          `json:"tranches"`
+	// number of blocks between two tranches
+	Interval int64  `json:"interval"`
+	Reason   string `json:"reason"`
+}
+
+func (c *GrantContent) amount() *big.Int {
+	amount, ok := new(big.Int).SetString(c.Amount, 10)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return amount
+}
+
+func (c *GrantContent) Validate(ctx types.Context) error {
+	if c.Tranches < 1 || c.Interval < 1 {
+		return ErrInvalidGrantSchedule()
+	}
+
+	amount := c.amount()
+	if amount.Sign() <= 0 {
+		return ErrInvalidParameter()
+	}
+
+	balance, err := commons.GetBalance(ctx.EthappState(), c.From)
+	if err != nil {
+		return ErrInvalidParameter()
+	}
+	if balance.Cmp(amount) < 0 {
+		return ErrInsufficientBalance()
+	}
+	return nil
+}
+
+func (c *GrantContent) OnSubmit(ctx *ProposalContext, p *Proposal) {
+	c.escrow(ctx.State)
+}
+
+// escrow moves the whole amount to the governance hold account when the proposal is submitted
+func (c *GrantContent) escrow(state *ethState.StateDB) {
+	state.SubBalance(c.From, c.amount())
+	state.AddBalance(utils.GovHoldAccount, c.amount())
+}
+
+func (c *GrantContent) refund(state *ethState.StateDB) {
+	state.SubBalance(utils.GovHoldAccount, c.amount())
+	state.AddBalance(c.From, c.amount())
+}
+
+// Execute starts the payout schedule, the amount stays escrowed until the tranches are released
+func (c *GrantContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	StartGrant(p.Id, c, ctx.BlockHeight)
+	return ""
+}
+
+func (c *GrantContent) OnReject(ctx *ProposalContext, p *Proposal) {
+	c.refund(ctx.State)
+}
+
+func (c *GrantContent) OnExpire(ctx *ProposalContext, p *Proposal) {
+	c.refund(ctx.State)
+}
+
+func (c *GrantContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_grant_detail(proposal_id, from_address, to_address, amount, tranches, tranche_interval, reason) values(?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pid, c.From.String(), c.To.String(), c.Amount, c.Tranches, c.Interval, c.Reason)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *GrantContent) Load(tx *sql.Tx, pid string) bool {
+	var fromAddr, toAddr string
+	err := tx.QueryRow("select from_address, to_address, amount, tranches, tranche_interval, reason from governance_grant_detail where proposal_id = ?", pid).Scan(&fromAddr, &toAddr, &c.Amount, &c.Tranches, &c.Interval, &c.Reason)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+
+	c.From = common.HexToAddress(fromAddr)
+	c.To = common.HexToAddress(toAddr)
+	return true
+}
+
+// CancelGrantContent cancels an active grant, the amount which hasn't been released yet
+// is clawed back to the account it was escrowed from
+type CancelGrantContent struct {
+	// id of the grant proposal
+	GrantId string `json:"grant_id"`
+	Reason  string `json:"reason"`
+}
+
+func (c *CancelGrantContent) Validate(ctx types.Context) error {
+	g := GetGrant(c.GrantId)
+	if g == nil {
+		return ErrGrantNotFound()
+	}
+	if g.Status != GRANT_STATUS_ACTIVE {
+		return ErrInactiveGrant()
+	}
+	return nil
+}
+
+// Execute claws back the remaining amount, the grant may have been completed since the proposal was submitted
+func (c *CancelGrantContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	if !ClawBackGrant(ctx.State, c.GrantId, ctx.BlockHeight) {
+		return errInactiveGrant.Error()
+	}
+	return ""
+}
+
+func (c *CancelGrantContent) OnReject(ctx *ProposalContext, p *Proposal) {}
+
+func (c *CancelGrantContent) OnExpire(ctx *ProposalContext, p *Proposal) {}
+
+func (c *CancelGrantContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_cancel_grant_detail(proposal_id, grant_id, reason) values(?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pid, c.GrantId, c.Reason)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *CancelGrantContent) Load(tx *sql.Tx, pid string) bool {
+	err := tx.QueryRow("select grant_id, reason from governance_cancel_grant_detail where proposal_id = ?", pid).Scan(&c.GrantId, &c.Reason)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+	return true
+}
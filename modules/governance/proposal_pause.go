@@ -0,0 +1,144 @@
+package governance
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+const (
+	EMERGENCY_PAUSE_PROPOSAL = "emergency_pause"
+	UNPAUSE_PROPOSAL         = "unpause"
+)
+
+func init() {
+	RegisterProposalType(EMERGENCY_PAUSE_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &PauseContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.EmergencyPauseProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.EmergencyPauseProposalQuorum, params.EmergencyPauseProposalThreshold
+		},
+	})
+	RegisterProposalType(UNPAUSE_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &UnpauseContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.UnpauseProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.UnpauseProposalQuorum, params.UnpauseProposalThreshold
+		},
+	})
+}
+
+// PauseContent pauses the EVM transactions, or only the calls to the contracts if any is given,
+// for duration blocks. Governance and stake transactions are never paused.
+type PauseContent struct {
+	Contracts []common.Address `json:"contracts"`
+	// defaults to the emergency_pause_duration param if 0
+	Duration int64  `json:"duration"`
+	Reason   string `json:"reason"`
+}
+
+func (c *PauseContent) duration() int64 {
+	if c.Duration == 0 {
+		return int64(utils.GetParams().EmergencyPauseDuration)
+	}
+	return c.Duration
+}
+
+func (c *PauseContent) Validate(ctx types.Context) error {
+	if d := c.duration(); d < 1 || d > int64(utils.GetParams().EmergencyPauseDuration) {
+		return ErrInvalidPauseDuration()
+	}
+	return nil
+}
+
+func (c *PauseContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	StartPause(p.Id, c, ctx.BlockHeight)
+	return ""
+}
+
+func (c *PauseContent) OnReject(ctx *ProposalContext, p *Proposal) {}
+
+func (c *PauseContent) OnExpire(ctx *ProposalContext, p *Proposal) {}
+
+func (c *PauseContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_pause_detail(proposal_id, contracts, duration, reason) values(?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	contracts, _ := json.Marshal(c.Contracts)
+	_, err = stmt.Exec(pid, string(contracts), c.Duration, c.Reason)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *PauseContent) Load(tx *sql.Tx, pid string) bool {
+	var contracts string
+	err := tx.QueryRow("select contracts, duration, reason from governance_pause_detail where proposal_id = ?", pid).Scan(&contracts, &c.Duration, &c.Reason)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+
+	if err = json.Unmarshal([]byte(contracts), &c.Contracts); err != nil {
+		panic(err)
+	}
+	return true
+}
+
+// UnpauseContent lifts the active emergency pause before its expiration
+type UnpauseContent struct {
+	Reason string `json:"reason"`
+}
+
+func (c *UnpauseContent) Validate(ctx types.Context) error {
+	if GetActivePause() == nil {
+		return ErrNotPaused()
+	}
+	return nil
+}
+
+// Execute lifts the pause which is active at the approval, the pause may have expired since the submission
+func (c *UnpauseContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	if !LiftPause(p.Id, ctx.BlockHeight) {
+		return errNotPaused.Error()
+	}
+	return ""
+}
+
+func (c *UnpauseContent) OnReject(ctx *ProposalContext, p *Proposal) {}
+
+func (c *UnpauseContent) OnExpire(ctx *ProposalContext, p *Proposal) {}
+
+func (c *UnpauseContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_unpause_detail(proposal_id, reason) values(?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pid, c.Reason)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *UnpauseContent) Load(tx *sql.Tx, pid string) bool {
+	err := tx.QueryRow("select reason from governance_unpause_detail where proposal_id = ?", pid).Scan(&c.Reason)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+	return true
+}
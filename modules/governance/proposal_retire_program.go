@@ -0,0 +1,148 @@
+package governance
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/vangjvn/devchain/modules/stake"
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+	"github.com/vangjvn/devchain/version"
+)
+
+const RETIRE_PROGRAM_PROPOSAL = "retire_program"
+
+func init() {
+	RegisterProposalType(RETIRE_PROGRAM_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &RetireProgramContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.RetireProgramProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.RetireProgramProposalQuorum, params.RetireProgramProposalThreshold
+		},
+	})
+}
+
+// RetireProgramContent stops the chain running the retired version at the expire block height,
+// only the preserved validators are kept until the end
+type RetireProgramContent struct {
+	RetiredVersion      string `json:"retired_version"`
+	PreservedValidators string `json:"preserved_validators"`
+	Reason              string `json:"reason"`
+	// retirement status, updated aside of the proposal
+	Status string `json:"status"`
+}
+
+func (c *RetireProgramContent) ExecuteAtExpiration() {}
+
+func (c *RetireProgramContent) CheckAhead() {}
+
+func (c *RetireProgramContent) Validate(ctx types.Context) error {
+	if GetRetiringProposal(version.Version) != nil {
+		return ErrOngoingRetiringFound()
+	}
+
+	if c.PreservedValidators == "" {
+		return ErrInvalidParameter()
+	}
+	return nil
+}
+
+func (c *RetireProgramContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	// process will be killed at next block
+	utils.RetiringProposalId = p.Id
+	return ""
+}
+
+func (c *RetireProgramContent) OnReject(ctx *ProposalContext, p *Proposal) {}
+
+func (c *RetireProgramContent) OnExpire(ctx *ProposalContext, p *Proposal) {}
+
+func (c *RetireProgramContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_retire_program_detail(proposal_id, retired_version, preserved_validators, reason, status) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pid, c.RetiredVersion, c.PreservedValidators, c.Reason, c.Status)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *RetireProgramContent) Load(tx *sql.Tx, pid string) bool {
+	err := tx.QueryRow("select retired_version, preserved_validators, reason, status from governance_retire_program_detail where proposal_id = ?", pid).Scan(&c.RetiredVersion, &c.PreservedValidators, &c.Reason, &c.Status)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+	return true
+}
+
+// ResumeRetirement is called with the last block height when the node starts. retired tells whether the program
+// has been retired at that height, relaunched whether the chain has just relaunched from a retired version.
+func ResumeRetirement(lastBlockHeight int64) (retired, relaunched bool) {
+	rp := GetRetiringProposal(version.Version)
+	if rp != nil {
+		if rp.ExpireBlockHeight <= lastBlockHeight {
+			rp = GetProposalById(rp.Id)
+			retired = rp.Content.(*RetireProgramContent).Status == "success"
+		} else if rp.ExpireBlockHeight == lastBlockHeight+1 {
+			if rp.Result == "Approved" {
+				utils.RetiringProposalId = rp.Id
+			}
+		} else {
+			// check ahead one block
+			utils.PendingProposal.Add(rp.Id, 0, rp.ExpireBlockHeight-1)
+		}
+	}
+	return retired, GetLatestRetiredHeight() == lastBlockHeight
+}
+
+// Retire deactivates the validators which are not in the list of the preserved validators of the approved retire_program proposal.
+// It returns the validator changes, and whether the program is retired at this block.
+func Retire() ([]abci.Validator, bool, error) {
+	if utils.RetiringProposalId == "" {
+		return nil, false, nil
+	}
+	proposal := GetProposalById(utils.RetiringProposalId)
+	if proposal == nil {
+		return nil, false, errors.New("Getting invalid RetiringProposalId")
+	}
+
+	pks := strings.Split(proposal.Content.(*RetireProgramContent).PreservedValidators, ",")
+	vs := stake.GetCandidates().Validators()
+	inaVs := make(stake.Validators, 0)
+	abciVs := make([]abci.Validator, 0)
+	pvSize := 0
+	for _, v := range vs {
+		i := 0
+		for ; i < len(pks); i++ {
+			if pks[i] == types.PubKeyString(v.PubKey) {
+				v.VotingPower = 1000
+				abciVs = append(abciVs, v.ABCIValidator())
+				pvSize++
+				break
+			}
+		}
+		if i == len(pks) {
+			inaVs = append(inaVs, v)
+			pk := v.PubKey.PubKey.(ed25519.PubKeyEd25519)
+			abciVs = append(abciVs, abci.Ed25519Validator(pk[:], 0))
+		}
+	}
+	if pvSize < 1 {
+		UpdateRetireProgramStatus(utils.RetiringProposalId, "rejected")
+		return nil, false, nil
+	}
+	inaVs.Deactivate()
+	UpdateRetireProgramStatus(utils.RetiringProposalId, "success")
+	return abciVs, true, nil
+}
@@ -0,0 +1,65 @@
+package governance
+
+import (
+	"database/sql"
+
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+const TEXT_PROPOSAL = "text"
+
+func init() {
+	RegisterProposalType(TEXT_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &TextContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.TextProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.TextProposalQuorum, params.TextProposalThreshold
+		},
+	})
+}
+
+// TextContent records a decision without on-chain execution, described by an off-chain document
+type TextContent struct {
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	DocumentUrl  string `json:"document_url"`
+	DocumentHash string `json:"document_hash"`
+}
+
+func (c *TextContent) Validate(ctx types.Context) error {
+	return nil
+}
+
+func (c *TextContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	return ""
+}
+
+func (c *TextContent) OnReject(ctx *ProposalContext, p *Proposal) {}
+
+func (c *TextContent) OnExpire(ctx *ProposalContext, p *Proposal) {}
+
+func (c *TextContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_text_detail(proposal_id, title, description, document_url, document_hash) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pid, c.Title, c.Description, c.DocumentUrl, c.DocumentHash)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *TextContent) Load(tx *sql.Tx, pid string) bool {
+	err := tx.QueryRow("select title, description, document_url, document_hash from governance_text_detail where proposal_id = ?", pid).Scan(&c.Title, &c.Description, &c.DocumentUrl, &c.DocumentHash)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextProposalRoundTrip(t *testing.T) {
+	defer setupTestDb(t)()
+
+	proposer := common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+	content := &TextContent{
+		Title:        "Adopt the validator code of conduct",
+		Description:  "Validators commit to the code of conduct from the next quarter",
+		DocumentUrl:  "https://example.com/code-of-conduct.pdf",
+		DocumentHash: "0x9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}
+	SaveProposal(&Proposal{
+		Id:                "text",
+		Type:              TEXT_PROPOSAL,
+		Proposer:          &proposer,
+		BlockHeight:       1,
+		ExpireBlockHeight: 100,
+		Deposit:           "0",
+		Content:           content,
+	})
+
+	p := GetProposalById("text")
+	require.NotNil(t, p)
+	require.Equal(t, TEXT_PROPOSAL, p.Type)
+	require.Equal(t, content, p.Content)
+
+	// an approval only records the decision
+	state := newTestState(t)
+	require.Equal(t, "", p.Content.Execute(&ProposalContext{State: state, BlockHeight: 10}, p))
+	require.Equal(t, state.IntermediateRoot(false), newTestState(t).IntermediateRoot(false))
+}
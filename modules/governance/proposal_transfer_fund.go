@@ -0,0 +1,118 @@
+package governance
+
+import (
+	"database/sql"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethState "github.com/ethereum/go-ethereum/core/state"
+
+	"github.com/vangjvn/devchain/commons"
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+const TRANSFER_FUND_PROPOSAL = "transfer_fund"
+
+func init() {
+	RegisterProposalType(TRANSFER_FUND_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &TransferFundContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.TransferFundProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.TransferFundProposalQuorum, params.TransferFundProposalThreshold
+		},
+	})
+}
+
+// TransferFundContent transfers an amount from an account to another,
+// the amount is escrowed in the governance hold account while the proposal is pending
+type TransferFundContent struct {
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Amount string         `json:"amount"`
+	Reason string         `json:"reason"`
+}
+
+func (c *TransferFundContent) amount() *big.Int {
+	amount, ok := new(big.Int).SetString(c.Amount, 10)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return amount
+}
+
+func (c *TransferFundContent) Validate(ctx types.Context) error {
+	amount := c.amount()
+	if amount.Sign() <= 0 {
+		return ErrInvalidParameter()
+	}
+
+	balance, err := commons.GetBalance(ctx.EthappState(), c.From)
+	if err != nil {
+		return ErrInvalidParameter()
+	}
+	if balance.Cmp(amount) < 0 {
+		return ErrInsufficientBalance()
+	}
+	return nil
+}
+
+func (c *TransferFundContent) Timelocked() {}
+
+func (c *TransferFundContent) OnSubmit(ctx *ProposalContext, p *Proposal) {
+	c.escrow(ctx.State)
+}
+
+// escrow moves the amount to the governance hold account when the proposal is submitted
+func (c *TransferFundContent) escrow(state *ethState.StateDB) {
+	state.SubBalance(c.From, c.amount())
+	state.AddBalance(utils.GovHoldAccount, c.amount())
+}
+
+func (c *TransferFundContent) release(state *ethState.StateDB, to common.Address) {
+	state.SubBalance(utils.GovHoldAccount, c.amount())
+	state.AddBalance(to, c.amount())
+}
+
+func (c *TransferFundContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	c.release(ctx.State, c.To)
+	return ""
+}
+
+// OnReject refunds the escrowed amount to the account it was transferred from
+func (c *TransferFundContent) OnReject(ctx *ProposalContext, p *Proposal) {
+	c.release(ctx.State, c.From)
+}
+
+func (c *TransferFundContent) OnExpire(ctx *ProposalContext, p *Proposal) {
+	c.release(ctx.State, c.From)
+}
+
+func (c *TransferFundContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_transfer_fund_detail(proposal_id, from_address, to_address, amount, reason) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pid, c.From.String(), c.To.String(), c.Amount, c.Reason)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *TransferFundContent) Load(tx *sql.Tx, pid string) bool {
+	var fromAddr, toAddr string
+	err := tx.QueryRow("select from_address, to_address, amount, reason from governance_transfer_fund_detail where proposal_id = ?", pid).Scan(&fromAddr, &toAddr, &c.Amount, &c.Reason)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+
+	c.From = common.HexToAddress(fromAddr)
+	c.To = common.HexToAddress(toAddr)
+	return true
+}
@@ -0,0 +1,65 @@
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethState "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/ethdb"
+
+	"github.com/vangjvn/devchain/utils"
+)
+
+func newTestState(t *testing.T) *ethState.StateDB {
+	state, err := ethState.New(common.Hash{}, ethState.NewDatabase(ethdb.NewMemDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+func TestCancelTransferFundRefundsEscrow(t *testing.T) {
+	state := newTestState(t)
+	from := common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+	to := common.HexToAddress("0x77beb894fc9b0ed41231e51f128a347043960a9d")
+	state.AddBalance(from, big.NewInt(1000))
+
+	p := &Proposal{Id: "cancelled", Proposer: &from}
+	content := &TransferFundContent{From: from, To: to, Amount: "400"}
+	ctx := &ProposalContext{State: state, BlockHeight: 10}
+
+	content.OnSubmit(ctx, p)
+	if got := state.GetBalance(from); got.Cmp(big.NewInt(600)) != 0 {
+		t.Fatalf("balance of the sender after the submission = %v, want 600", got)
+	}
+	if got := state.GetBalance(utils.GovHoldAccount); got.Cmp(big.NewInt(400)) != 0 {
+		t.Fatalf("escrowed amount = %v, want 400", got)
+	}
+
+	// a cancellation reverts the submission as a rejection does
+	content.OnReject(ctx, p)
+	if got := state.GetBalance(from); got.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("balance of the sender after the cancellation = %v, want 1000", got)
+	}
+	if got := state.GetBalance(utils.GovHoldAccount); got.Sign() != 0 {
+		t.Errorf("escrowed amount after the cancellation = %v, want 0", got)
+	}
+	if got := state.GetBalance(to); got.Sign() != 0 {
+		t.Errorf("balance of the recipient = %v, want 0", got)
+	}
+}
+
+func TestCancelDecidedProposal(t *testing.T) {
+	for result, want := range map[string]error{
+		"Approved":  ErrApprovedProposal(),
+		"Queued":    ErrApprovedProposal(),
+		"Cancelled": ErrCancelledProposal(),
+		"Rejected":  ErrRejectedProposal(),
+	} {
+		got := errDecidedProposal(&Proposal{Result: result, ResultBlockHeight: 5})
+		if got.Error() != want.Error() {
+			t.Errorf("%s: got %q, want %q", result, got, want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package governance
+
+import (
+	"database/sql"
+
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+const UPGRADE_PROGRAM_PROPOSAL = "upgrade_program"
+
+func init() {
+	RegisterProposalType(UPGRADE_PROGRAM_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &UpgradeProgramContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.UpgradeProgramProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.UpgradeProgramProposalQuorum, params.UpgradeProgramProposalThreshold
+		},
+	})
+}
+
+// UpgradeProgramContent swaps the running version for the release at the expire block height
+type UpgradeProgramContent struct {
+	RetiredVersion string `json:"retired_version"`
+	Release
+	Reason string `json:"reason"`
+}
+
+func (c *UpgradeProgramContent) ExecuteAtExpiration() {}
+
+// OnSubmit has the monitor download the release, for it to be ready when the proposal is approved
+func (c *UpgradeProgramContent) OnSubmit(ctx *ProposalContext, p *Proposal) {
+	DownloadProgramCmd(p)
+}
+
+func (c *UpgradeProgramContent) Validate(ctx types.Context) error {
+	return c.Release.validate()
+}
+
+func (c *UpgradeProgramContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	// Upgrade program command to new version
+	UpgradeProgramCmd(p)
+	return ""
+}
+
+func (c *UpgradeProgramContent) OnReject(ctx *ProposalContext, p *Proposal) {}
+
+func (c *UpgradeProgramContent) OnExpire(ctx *ProposalContext, p *Proposal) {}
+
+func (c *UpgradeProgramContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_upgrade_program_detail(proposal_id, retired_version, name, version, fileurl, md5, sha256, signature, reason) values(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pid, c.RetiredVersion, c.Name, c.Version, c.FileUrl, c.Md5, c.Sha256, c.Signature, c.Reason)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *UpgradeProgramContent) Load(tx *sql.Tx, pid string) bool {
+	err := tx.QueryRow("select retired_version, name, version, fileurl, md5, sha256, signature, reason from governance_upgrade_program_detail where proposal_id = ?", pid).Scan(&c.RetiredVersion, &c.Name, &c.Version, &c.FileUrl, &c.Md5, &c.Sha256, &c.Signature, &c.Reason)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package governance
+
+import (
+	"database/sql"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/vangjvn/devchain/modules/stake"
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+const ADD_VALIDATOR_PROPOSAL = "add_validator"
+const REMOVE_VALIDATOR_PROPOSAL = "remove_validator"
+
+func init() {
+	RegisterProposalType(ADD_VALIDATOR_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &ValidatorContent{admit: true} },
+		Gas:        func(params *utils.Params) uint64 { return params.AddValidatorProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.AddValidatorProposalQuorum, params.AddValidatorProposalThreshold
+		},
+	})
+	RegisterProposalType(REMOVE_VALIDATOR_PROPOSAL, ProposalType{
+		NewContent: func() ProposalContent { return &ValidatorContent{} },
+		Gas:        func(params *utils.Params) uint64 { return params.RemoveValidatorProposalGas },
+		Thresholds: func(params *utils.Params) (sdk.Rat, sdk.Rat) {
+			return params.RemoveValidatorProposalQuorum, params.RemoveValidatorProposalThreshold
+		},
+	})
+}
+
+// ValidatorContent admits a declared candidate to the validator set, or evicts it
+type ValidatorContent struct {
+	Candidate common.Address `json:"candidate"`
+	Reason    string         `json:"reason"`
+	admit     bool
+}
+
+func NewValidatorContent(ptype string, candidate common.Address, reason string) *ValidatorContent {
+	return &ValidatorContent{candidate, reason, ptype == ADD_VALIDATOR_PROPOSAL}
+}
+
+func (c *ValidatorContent) Validate(ctx types.Context) error {
+	candidate := stake.GetCandidateByAddress(c.Candidate)
+	if candidate == nil {
+		return ErrCandidateNotFound()
+	}
+
+	if c.admit {
+		if candidate.IsAdmitted() {
+			return ErrCandidateAdmitted()
+		}
+		return nil
+	}
+
+	validators := stake.GetCandidates().Validators()
+	if validators.Len() == 1 && isValidatorOwner(validators, c.Candidate) {
+		return ErrLastValidator()
+	}
+	return nil
+}
+
+// Execute admits or evicts the candidate, it returns the error message if the candidate can't be found any more
+func (c *ValidatorContent) Execute(ctx *ProposalContext, p *Proposal) string {
+	var err error
+	if c.admit {
+		err = stake.AdmitCandidate(c.Candidate)
+	} else {
+		err = stake.EvictCandidate(c.Candidate)
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+func (c *ValidatorContent) OnReject(ctx *ProposalContext, p *Proposal) {}
+
+func (c *ValidatorContent) OnExpire(ctx *ProposalContext, p *Proposal) {}
+
+func (c *ValidatorContent) Save(tx *sql.Tx, pid string) {
+	stmt, err := tx.Prepare("insert into governance_validator_detail(proposal_id, candidate_address, reason) values(?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pid, c.Candidate.String(), c.Reason)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (c *ValidatorContent) Load(tx *sql.Tx, pid string) bool {
+	var candidate string
+	err := tx.QueryRow("select candidate_address, reason from governance_validator_detail where proposal_id = ?", pid).Scan(&candidate, &c.Reason)
+	switch {
+	case err == sql.ErrNoRows:
+		return false
+	case err != nil:
+		panic(err)
+	}
+
+	c.Candidate = common.HexToAddress(candidate)
+	return true
+}
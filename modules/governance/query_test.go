@@ -0,0 +1,94 @@
+package governance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryProposalsByFilter(t *testing.T) {
+	defer setupTestDb(t)()
+
+	alice := common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+	bob := common.HexToAddress("0x77beb894fc9b0ed41231e51f128a347043960a9d")
+
+	// p1..p6 created at the heights 10..60, alternately by alice and bob
+	for i := 1; i <= 6; i++ {
+		proposer := alice
+		if i%2 == 0 {
+			proposer = bob
+		}
+		SaveProposal(&Proposal{
+			Id:                fmt.Sprintf("p%d", i),
+			Type:              TEXT_PROPOSAL,
+			Proposer:          &proposer,
+			BlockHeight:       int64(i * 10),
+			ExpireBlockHeight: 1000,
+			Deposit:           "0",
+			Content:           &TextContent{Title: fmt.Sprintf("proposal %d", i)},
+		})
+	}
+	UpdateProposalResult("p1", "Approved", "", 100)
+	UpdateProposalResult("p2", "Rejected", "", 100)
+
+	ids := func(filter ProposalFilter) (total int64, ids []string) {
+		page := QueryProposalsByFilter(filter)
+		for _, p := range page.Proposals {
+			ids = append(ids, p.Id)
+		}
+		return page.Total, ids
+	}
+
+	cases := []struct {
+		filter ProposalFilter
+		total  int64
+		ids    []string
+	}{
+		{ProposalFilter{}, 6, []string{"p6", "p5", "p4", "p3", "p2", "p1"}},
+		{ProposalFilter{Proposer: bob.Hex()}, 3, []string{"p6", "p4", "p2"}},
+		{ProposalFilter{Status: "Approved"}, 1, []string{"p1"}},
+		{ProposalFilter{Status: PENDING_PROPOSAL_STATUS}, 4, []string{"p6", "p5", "p4", "p3"}},
+		{ProposalFilter{FromHeight: 20, ToHeight: 40}, 3, []string{"p4", "p3", "p2"}},
+		{ProposalFilter{Type: CHANGE_PARAM_PROPOSAL}, 0, nil},
+		{ProposalFilter{PerPage: 4, Page: 2}, 6, []string{"p2", "p1"}},
+		{ProposalFilter{Proposer: alice.Hex(), Status: PENDING_PROPOSAL_STATUS, PerPage: 1}, 2, []string{"p5"}},
+	}
+	for _, c := range cases {
+		total, got := ids(c.filter)
+		assert.Equal(t, c.total, total, "%+v", c.filter)
+		assert.Equal(t, c.ids, got, "%+v", c.filter)
+	}
+}
+
+func TestProposalFilterPagination(t *testing.T) {
+	for _, c := range [][4]int{
+		// page, per page requested, and the ones returned
+		{0, 0, 1, DEFAULT_PROPOSALS_PER_PAGE},
+		{3, 10, 3, 10},
+		{-1, MAX_PROPOSALS_PER_PAGE + 1, 1, MAX_PROPOSALS_PER_PAGE},
+	} {
+		page, perPage := ProposalFilter{Page: c[0], PerPage: c[1]}.Pagination()
+		assert.Equal(t, [2]int{c[2], c[3]}, [2]int{page, perPage}, "page %d, per page %d", c[0], c[1])
+	}
+}
+
+func TestQueryVotes(t *testing.T) {
+	defer setupTestDb(t)()
+
+	alice := common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+	bob := common.HexToAddress("0x77beb894fc9b0ed41231e51f128a347043960a9d")
+	SaveVote(NewVote("p1", alice, 10, VOTE_YES))
+	SaveVote(NewVote("p1", bob, 11, VOTE_NO))
+	SaveVote(NewVote("p2", alice, 20, VOTE_ABSTAIN))
+
+	assert.Len(t, QueryVotesByPid("p1"), 2)
+	assert.Empty(t, QueryVotesByPid("p3"))
+
+	votes := QueryVotesByVoter(alice.Hex())
+	if assert.Len(t, votes, 2) {
+		assert.Equal(t, NewVote("p2", alice, 20, VOTE_ABSTAIN), votes[0], "latest vote first")
+		assert.Equal(t, NewVote("p1", alice, 10, VOTE_YES), votes[1])
+	}
+}
@@ -0,0 +1,55 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+func TestValidatorSnapshot(t *testing.T) {
+	defer setupTestDb(t)()
+	utils.SetParams(utils.DefaultParams())
+
+	alice := common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+	bob := common.HexToAddress("0x77beb894fc9b0ed41231e51f128a347043960a9d")
+	carol := common.HexToAddress("0x84f444c0405c761f5a2b6ac6ee6fb4ebc7a8c4b3")
+	saveValidator(alice, 1000)
+	saveValidator(bob, 1000)
+	SaveVoteDelegation(&VoteDelegation{alice, bob, 1})
+
+	SaveProposal(&Proposal{Id: "p1", Type: TEXT_PROPOSAL, Proposer: &alice, BlockHeight: 2,
+		ExpireBlockHeight: 1000, Deposit: "0", Content: &TextContent{Title: "snapshot"}})
+
+	// the validator set and the delegations change after the creation of the proposal
+	saveValidator(carol, 1000)
+	DeleteVoteDelegation(alice)
+
+	snapshot := QueryValidatorSnapshot("p1")
+	assert.Len(t, snapshot, 2)
+	for _, v := range snapshot {
+		if v.OwnerAddress == alice.String() {
+			assert.Equal(t, bob.String(), v.Delegatee, "the delegation at the creation is kept")
+		}
+		assert.NotEqual(t, carol.String(), v.OwnerAddress, "a later validator is left out")
+	}
+
+	vote := func(voter common.Address) error {
+		ctx := types.NewContext("test", 5, 0, nil)
+		ctx.WithSigners(voter)
+		_, err := CheckTx(ctx, nil, NewTxVote("p1", VOTE_YES))
+		return err
+	}
+	assert.NoError(t, vote(alice))
+	assert.Equal(t, ErrInvalidValidator().Error(), vote(carol).Error())
+
+	// the vote of bob carries the power delegated by alice at the creation of the proposal
+	SaveVote(NewVote("p1", bob, 5, VOTE_YES))
+	assert.Equal(t, "approved", CheckProposal("p1", nil, 1000))
+	tally := QueryTallyByPid("p1")
+	assert.Equal(t, int64(2000), tally.YesPower)
+	assert.Equal(t, int64(2000), tally.TotalPower)
+}
@@ -0,0 +1,40 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxVoteValidateBasic(t *testing.T) {
+	for _, answer := range []string{VOTE_YES, VOTE_NO, VOTE_ABSTAIN, VOTE_NO_WITH_VETO} {
+		assert.NoError(t, TxVote{"pid", answer}.ValidateBasic(), answer)
+	}
+	for _, answer := range []string{"", "y", "Yes", "X"} {
+		assert.Error(t, TxVote{"pid", answer}.ValidateBasic(), answer)
+	}
+}
+
+func TestSaveTally(t *testing.T) {
+	defer setupTestDb(t)()
+
+	assert.Nil(t, QueryTallyByPid("pid"))
+
+	SaveTally(NewTally("pid", 10, 5, 20, 0, 100, 3))
+	tally := QueryTallyByPid("pid")
+	if assert.NotNil(t, tally) {
+		assert.Equal(t, NewTally("pid", 10, 5, 20, 0, 100, 3), tally)
+		assert.Equal(t, "0.3500", tally.Turnout, "abstentions count toward the turnout")
+	}
+
+	// a later tally of the proposal replaces the earlier one
+	SaveTally(NewTally("pid", 10, 5, 20, 40, 100, 4))
+	tally = QueryTallyByPid("pid")
+	assert.Equal(t, int64(40), tally.VetoPower)
+	assert.Equal(t, int64(4), tally.BlockHeight)
+	assert.Equal(t, "0.7500", tally.Turnout)
+}
+
+func TestTallyTurnoutWithoutPower(t *testing.T) {
+	assert.Equal(t, "0", NewTally("pid", 0, 0, 0, 0, 0, 1).Turnout)
+}
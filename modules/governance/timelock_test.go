@@ -0,0 +1,74 @@
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vangjvn/devchain/utils"
+)
+
+func TestQueueProposal(t *testing.T) {
+	defer setupTestDb(t)()
+
+	proposer := common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+	to := common.HexToAddress("0x77beb894fc9b0ed41231e51f128a347043960a9d")
+	state := newTestState(t)
+	state.AddBalance(utils.GovHoldAccount, big.NewInt(300))
+
+	p := &Proposal{
+		Id:                "timelocked",
+		Type:              TRANSFER_FUND_PROPOSAL,
+		Proposer:          &proposer,
+		BlockHeight:       1,
+		ExpireBlockHeight: 100,
+		Deposit:           "0",
+		ExecutionDelay:    10,
+		Content:           &TransferFundContent{From: proposer, To: to, Amount: "300"},
+	}
+	SaveProposal(p)
+	utils.PendingProposal.Add(p.Id, 0, p.ExpireBlockHeight)
+	defer utils.PendingProposal.Del(p.Id)
+
+	// approved at 20, executed at 30 instead of the expiration
+	require.True(t, QueueProposal(p, 20))
+	queued := GetProposalById(p.Id)
+	require.Equal(t, "Queued", queued.Result)
+	require.Equal(t, int64(20), queued.ResultBlockHeight)
+	require.Equal(t, int64(30), queued.ExecuteBlockHeight)
+
+	ProcessPendingProposals(state, nil, 0, 29)
+	require.Equal(t, "Queued", GetProposalById(p.Id).Result)
+	require.Equal(t, int64(0), state.GetBalance(to).Int64())
+
+	ProcessPendingProposals(state, nil, 0, 30)
+	executed := GetProposalById(p.Id)
+	require.Equal(t, "Approved", executed.Result)
+	require.Equal(t, int64(30), executed.ResultBlockHeight)
+	require.Equal(t, int64(300), state.GetBalance(to).Int64())
+	require.Equal(t, int64(0), state.GetBalance(utils.GovHoldAccount).Int64())
+}
+
+func TestQueueProposalNotTimelocked(t *testing.T) {
+	// no delay, or an activation block height already reached, executes right away
+	require.False(t, QueueProposal(&Proposal{Id: "no delay"}, 20))
+	require.False(t, QueueProposal(&Proposal{Id: "activated", ExecutionDelay: 10, ActivationBlockHeight: 20}, 20))
+}
+
+func TestResolveTimelock(t *testing.T) {
+	params := utils.DefaultParams()
+	params.ProposalExecutionDelay = 5
+	utils.SetParams(params)
+	defer utils.SetParams(utils.DefaultParams())
+
+	delay, activation := int64(8), int64(50)
+
+	d, a := resolveTimelock(nil, nil)
+	require.Equal(t, [2]int64{5, 0}, [2]int64{d, a}, "default delay")
+	d, a = resolveTimelock(&delay, nil)
+	require.Equal(t, [2]int64{8, 0}, [2]int64{d, a}, "delay of the proposal")
+	d, a = resolveTimelock(&delay, &activation)
+	require.Equal(t, [2]int64{0, 50}, [2]int64{d, a}, "activation block height")
+}
@@ -1,7 +1,10 @@
 package governance
 
 import (
+	"strings"
+
 	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/version"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -18,12 +21,36 @@ const (
 	ByteTxRetireProgramPropose     = 0xA4
 	ByteTxUpgradeProgramPropose    = 0xA5
 	ByteTxVote                     = 0xA6
+	ByteTxCancelProposal           = 0xA7
+	ByteTxTextPropose              = 0xA8
+	ByteTxDelegateVote             = 0xA9
+	ByteTxRevokeVoteDelegation     = 0xAA
+	ByteTxAddValidatorPropose      = 0xAB
+	ByteTxRemoveValidatorPropose   = 0xAC
+	ByteTxGrantPropose             = 0xAD
+	ByteTxCancelGrantPropose       = 0xAE
+	ByteTxContractCallPropose      = 0xAF
+	ByteTxEmergencyPausePropose    = 0xB0
+	ByteTxUnpausePropose           = 0xB1
+	ByteTxConsensusParamsPropose   = 0xB2
 	TypeTxTransferFundPropose      = governanceModuleName + "/propose/transfer_fund"
 	TypeTxChangeParamPropose       = governanceModuleName + "/propose/change_param"
 	TypeTxDeployLibEniPropose      = governanceModuleName + "/propose/deploy_libeni"
 	TypeTxRetireProgramPropose     = governanceModuleName + "/propose/retire_program"
 	TypeTxUpgradeProgramPropose    = governanceModuleName + "/propose/upgrade_program"
 	TypeTxVote                     = governanceModuleName + "/vote"
+	TypeTxCancelProposal           = governanceModuleName + "/cancel"
+	TypeTxTextPropose              = governanceModuleName + "/propose/text"
+	TypeTxDelegateVote             = governanceModuleName + "/delegate"
+	TypeTxRevokeVoteDelegation     = governanceModuleName + "/revoke_delegation"
+	TypeTxAddValidatorPropose      = governanceModuleName + "/propose/add_validator"
+	TypeTxRemoveValidatorPropose   = governanceModuleName + "/propose/remove_validator"
+	TypeTxGrantPropose             = governanceModuleName + "/propose/grant"
+	TypeTxCancelGrantPropose       = governanceModuleName + "/propose/cancel_grant"
+	TypeTxContractCallPropose      = governanceModuleName + "/propose/contract_call"
+	TypeTxEmergencyPausePropose    = governanceModuleName + "/propose/emergency_pause"
+	TypeTxUnpausePropose           = governanceModuleName + "/propose/unpause"
+	TypeTxConsensusParamsPropose   = governanceModuleName + "/propose/consensus_params"
 )
 
 func init() {
@@ -33,11 +60,46 @@ func init() {
 	sdk.TxMapper.RegisterImplementation(TxRetireProgramPropose{}, TypeTxRetireProgramPropose, ByteTxRetireProgramPropose)
 	sdk.TxMapper.RegisterImplementation(TxUpgradeProgramPropose{}, TypeTxUpgradeProgramPropose, ByteTxUpgradeProgramPropose)
 	sdk.TxMapper.RegisterImplementation(TxVote{}, TypeTxVote, ByteTxVote)
+	sdk.TxMapper.RegisterImplementation(TxCancelProposal{}, TypeTxCancelProposal, ByteTxCancelProposal)
+	sdk.TxMapper.RegisterImplementation(TxTextPropose{}, TypeTxTextPropose, ByteTxTextPropose)
+	sdk.TxMapper.RegisterImplementation(TxDelegateVote{}, TypeTxDelegateVote, ByteTxDelegateVote)
+	sdk.TxMapper.RegisterImplementation(TxRevokeVoteDelegation{}, TypeTxRevokeVoteDelegation, ByteTxRevokeVoteDelegation)
+	sdk.TxMapper.RegisterImplementation(TxAddValidatorPropose{}, TypeTxAddValidatorPropose, ByteTxAddValidatorPropose)
+	sdk.TxMapper.RegisterImplementation(TxRemoveValidatorPropose{}, TypeTxRemoveValidatorPropose, ByteTxRemoveValidatorPropose)
+	sdk.TxMapper.RegisterImplementation(TxGrantPropose{}, TypeTxGrantPropose, ByteTxGrantPropose)
+	sdk.TxMapper.RegisterImplementation(TxCancelGrantPropose{}, TypeTxCancelGrantPropose, ByteTxCancelGrantPropose)
+	sdk.TxMapper.RegisterImplementation(TxContractCallPropose{}, TypeTxContractCallPropose, ByteTxContractCallPropose)
+	sdk.TxMapper.RegisterImplementation(TxEmergencyPausePropose{}, TypeTxEmergencyPausePropose, ByteTxEmergencyPausePropose)
+	sdk.TxMapper.RegisterImplementation(TxUnpausePropose{}, TypeTxUnpausePropose, ByteTxUnpausePropose)
+	sdk.TxMapper.RegisterImplementation(TxConsensusParamsPropose{}, TypeTxConsensusParamsPropose, ByteTxConsensusParamsPropose)
 }
 
 //Verify interface at compile time
 var _, _, _, _, _ sdk.TxInner = &TxTransferFundPropose{}, &TxChangeParamPropose{}, &TxDeployLibEniPropose{}, &TxRetireProgramPropose{}, &TxUpgradeProgramPropose{}
 var _ sdk.TxInner = &TxVote{}
+var _ sdk.TxInner = &TxCancelProposal{}
+var _ sdk.TxInner = &TxTextPropose{}
+var _, _ sdk.TxInner = &TxDelegateVote{}, &TxRevokeVoteDelegation{}
+var _, _ sdk.TxInner = &TxAddValidatorPropose{}, &TxRemoveValidatorPropose{}
+var _, _ sdk.TxInner = &TxGrantPropose{}, &TxCancelGrantPropose{}
+var _ sdk.TxInner = &TxContractCallPropose{}
+var _, _ sdk.TxInner = &TxEmergencyPausePropose{}, &TxUnpausePropose{}
+var _ sdk.TxInner = &TxConsensusParamsPropose{}
+
+// proposeRequest is the proposal submitted by a propose tx, the options the tx doesn't support are nil
+type proposeRequest struct {
+	Type                  string
+	Content               ProposalContent
+	ExpireTimestamp       *int64
+	ExpireBlockHeight     *int64
+	ExecutionDelay        *int64
+	ActivationBlockHeight *int64
+}
+
+// proposeTx is implemented by the txs submitting a proposal, which are all handled by the same path
+type proposeTx interface {
+	proposal() *proposeRequest
+}
 
 type TxTransferFundPropose struct {
 	From               *common.Address   `json:"transfer_from"`
@@ -46,13 +108,24 @@ type TxTransferFundPropose struct {
 	Reason             string            `json:"reason"`
 	ExpireTimestamp    *int64            `json:"expire_timestamp"`
 	ExpireBlockHeight  *int64            `json:"expire_block_height"`
+	// overrides the proposal_execution_delay param, exclusive with ActivationBlockHeight
+	ExecutionDelay        *int64 `json:"execution_delay,omitempty"`
+	ActivationBlockHeight *int64 `json:"activation_block_height,omitempty"`
 }
 
 func (tx TxTransferFundPropose) ValidateBasic() error {
-	return nil
+	if tx.From == nil || tx.To == nil {
+		return ErrInvalidParameter()
+	}
+	return validateTimelock(tx.ExecutionDelay, tx.ActivationBlockHeight)
+}
+
+// Content returns the content of the proposal submitted by the tx
+func (tx TxTransferFundPropose) Content() *TransferFundContent {
+	return &TransferFundContent{*tx.From, *tx.To, tx.Amount, tx.Reason}
 }
 
-func NewTxTransferFundPropose(fromAddr *common.Address, toAddr *common.Address, amount string, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+func NewTxTransferFundPropose(fromAddr *common.Address, toAddr *common.Address, amount string, reason string, expireTimestamp, expireBlockHeight, executionDelay, activationBlockHeight *int64) sdk.Tx {
 	return TxTransferFundPropose{
 		fromAddr,
 		toAddr,
@@ -60,35 +133,104 @@ func NewTxTransferFundPropose(fromAddr *common.Address, toAddr *common.Address,
 		reason,
 		expireTimestamp,
 		expireBlockHeight,
+		executionDelay,
+		activationBlockHeight,
 	}.Wrap()
 }
 
+func (tx TxTransferFundPropose) proposal() *proposeRequest {
+	return &proposeRequest{TRANSFER_FUND_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, tx.ExecutionDelay, tx.ActivationBlockHeight}
+}
+
 func (tx TxTransferFundPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
 
 type TxChangeParamPropose struct {
-	Name                  string   `json:"name"`
-	Value                 string   `json:"value"`
-	Reason                string   `json:"reason"`
-	ExpireTimestamp       *int64   `json:"expire_timestamp"`
-	ExpireBlockHeight     *int64   `json:"expire_block_height"`
+	Name                  string          `json:"name"`
+	Value                 string          `json:"value"`
+	Params                []ParamChange   `json:"params,omitempty"`
+	Reason                string          `json:"reason"`
+	ExpireTimestamp       *int64          `json:"expire_timestamp"`
+	ExpireBlockHeight     *int64          `json:"expire_block_height"`
+	ExecutionDelay        *int64          `json:"execution_delay,omitempty"`
+	ActivationBlockHeight *int64          `json:"activation_block_height,omitempty"`
 }
 
 func (tx TxChangeParamPropose) ValidateBasic() error {
+	if err := validateTimelock(tx.ExecutionDelay, tx.ActivationBlockHeight); err != nil {
+		return err
+	}
+	// either a single name/value pair or a list of them
+	if tx.Name != "" && len(tx.Params) > 0 {
+		return ErrInvalidParameter()
+	}
+	names := make(map[string]bool)
+	for _, p := range tx.Params {
+		if p.Name == "" || names[p.Name] {
+			return ErrInvalidParameter()
+		}
+		names[p.Name] = true
+	}
 	return nil
 }
 
-func NewTxChangeParamPropose(name string, value string, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+// ParamChanges returns the name/value pairs to be changed by the proposal
+func (tx TxChangeParamPropose) ParamChanges() []ParamChange {
+	if len(tx.Params) > 0 {
+		return tx.Params
+	}
+	return []ParamChange{{tx.Name, tx.Value}}
+}
+
+func (tx TxChangeParamPropose) Content() *ChangeParamContent {
+	return NewChangeParamContent(tx.ParamChanges(), tx.Reason)
+}
+
+func NewTxChangeParamPropose(name string, value string, reason string, expireTimestamp, expireBlockHeight, executionDelay, activationBlockHeight *int64) sdk.Tx {
 	return TxChangeParamPropose{
 		name,
 		value,
+		nil,
 		reason,
 		expireTimestamp,
 		expireBlockHeight,
+		executionDelay,
+		activationBlockHeight,
 	}.Wrap()
 }
 
+func NewTxChangeParamsPropose(params []ParamChange, reason string, expireTimestamp, expireBlockHeight, executionDelay, activationBlockHeight *int64) sdk.Tx {
+	return TxChangeParamPropose{
+		"",
+		"",
+		params,
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+		executionDelay,
+		activationBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxChangeParamPropose) proposal() *proposeRequest {
+	return &proposeRequest{CHANGE_PARAM_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, tx.ExecutionDelay, tx.ActivationBlockHeight}
+}
+
 func (tx TxChangeParamPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
 
+// validateTimelock checks that at most one of the execution delay and the activation block height is given
+func validateTimelock(executionDelay, activationBlockHeight *int64) error {
+	if executionDelay != nil && activationBlockHeight != nil {
+		return ErrInvalidTimelock()
+	}
+	if executionDelay != nil && *executionDelay < 0 {
+		return ErrInvalidTimelock()
+	}
+	if activationBlockHeight != nil && *activationBlockHeight <= 0 {
+		return ErrInvalidTimelock()
+	}
+	return nil
+}
+
 type TxDeployLibEniPropose struct {
 	Name                  string   `json:"name"`
 	Version               string   `json:"version"`
@@ -97,13 +239,20 @@ type TxDeployLibEniPropose struct {
 	Reason                string   `json:"reason"`
 	ExpireTimestamp       *int64   `json:"deploy_timestamp"`
 	ExpireBlockHeight     *int64   `json:"deploy_block_height"`
+	// SHA-256 digests and their signatures per platform, encoded in json
+	Sha256                string   `json:"sha256,omitempty"`
+	Signature             string   `json:"signature,omitempty"`
 }
 
 func (tx TxDeployLibEniPropose) ValidateBasic() error {
 	return nil
 }
 
-func NewTxDeployLibEniPropose(name, version, fileurl, md5, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+func (tx TxDeployLibEniPropose) Content() *DeployLibEniContent {
+	return &DeployLibEniContent{Release{tx.Name, tx.Version, tx.FileUrl, tx.Md5, tx.Sha256, tx.Signature}, tx.Reason, "init"}
+}
+
+func NewTxDeployLibEniPropose(name, version, fileurl, md5, sha256, signature, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
 	return TxDeployLibEniPropose {
 		name,
 		version,
@@ -112,9 +261,15 @@ func NewTxDeployLibEniPropose(name, version, fileurl, md5, reason string, expire
 		reason,
 		expireTimestamp,
 		expireBlockHeight,
+		sha256,
+		signature,
 	}.Wrap()
 }
 
+func (tx TxDeployLibEniPropose) proposal() *proposeRequest {
+	return &proposeRequest{DEPLOY_LIBENI_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, nil, nil}
+}
+
 func (tx TxDeployLibEniPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
 
 type TxRetireProgramPropose struct {
@@ -127,6 +282,11 @@ func (tx TxRetireProgramPropose) ValidateBasic() error {
 	return nil
 }
 
+// Content returns the content retiring the running version
+func (tx TxRetireProgramPropose) Content() *RetireProgramContent {
+	return &RetireProgramContent{version.Version, tx.PreservedValidators, tx.Reason, ""}
+}
+
 func NewTxRetireProgramPropose(preservedValidators, reason string, expireBlockHeight *int64) sdk.Tx {
 	return TxRetireProgramPropose {
 		preservedValidators,
@@ -135,6 +295,10 @@ func NewTxRetireProgramPropose(preservedValidators, reason string, expireBlockHe
 	}.Wrap()
 }
 
+func (tx TxRetireProgramPropose) proposal() *proposeRequest {
+	return &proposeRequest{RETIRE_PROGRAM_PROPOSAL, tx.Content(), nil, tx.ExpireBlockHeight, nil, nil}
+}
+
 func (tx TxRetireProgramPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
 
 type TxUpgradeProgramPropose struct {
@@ -144,13 +308,20 @@ type TxUpgradeProgramPropose struct {
 	Md5                string          `json:"md5"`
 	Reason             string          `json:"reason"`
 	ExpireBlockHeight  *int64          `json:"upgrade_block_height"`
+	Sha256             string          `json:"sha256,omitempty"`
+	Signature          string          `json:"signature,omitempty"`
 }
 
 func (tx TxUpgradeProgramPropose) ValidateBasic() error {
 	return nil
 }
 
-func NewTxUpgradeProgramPropose(name, version, fileurl, md5, reason string, expireBlockHeight *int64) sdk.Tx {
+// Content returns the content upgrading the running version
+func (tx TxUpgradeProgramPropose) Content() *UpgradeProgramContent {
+	return &UpgradeProgramContent{version.Version, Release{tx.Name, tx.Version, tx.FileUrl, tx.Md5, tx.Sha256, tx.Signature}, tx.Reason}
+}
+
+func NewTxUpgradeProgramPropose(name, version, fileurl, md5, sha256, signature, reason string, expireBlockHeight *int64) sdk.Tx {
 	return TxUpgradeProgramPropose {
 		name,
 		version,
@@ -158,18 +329,69 @@ func NewTxUpgradeProgramPropose(name, version, fileurl, md5, reason string, expi
 		md5,
 		reason,
 		expireBlockHeight,
+		sha256,
+		signature,
 	}.Wrap()
 }
 
+func (tx TxUpgradeProgramPropose) proposal() *proposeRequest {
+	return &proposeRequest{UPGRADE_PROGRAM_PROPOSAL, tx.Content(), nil, tx.ExpireBlockHeight, nil, nil}
+}
+
 func (tx TxUpgradeProgramPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
 
+type TxTextPropose struct {
+	Title              string          `json:"title"`
+	Description        string          `json:"description"`
+	DocumentUrl        string          `json:"document_url"`
+	DocumentHash       string          `json:"document_hash"`
+	ExpireTimestamp    *int64          `json:"expire_timestamp"`
+	ExpireBlockHeight  *int64          `json:"expire_block_height"`
+}
+
+func (tx TxTextPropose) ValidateBasic() error {
+	if strings.TrimSpace(tx.Title) == "" {
+		return ErrInsufficientParameters()
+	}
+	// the document is optional, but its content must be verifiable
+	if tx.DocumentUrl != "" && tx.DocumentHash == "" {
+		return ErrInsufficientParameters()
+	}
+	return nil
+}
+
+func (tx TxTextPropose) Content() *TextContent {
+	return &TextContent{tx.Title, tx.Description, tx.DocumentUrl, tx.DocumentHash}
+}
+
+func NewTxTextPropose(title, description, documentUrl, documentHash string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+	return TxTextPropose{
+		title,
+		description,
+		documentUrl,
+		documentHash,
+		expireTimestamp,
+		expireBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxTextPropose) proposal() *proposeRequest {
+	return &proposeRequest{TEXT_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, nil, nil}
+}
+
+func (tx TxTextPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
 type TxVote struct {
 	ProposalId       string            `json:"proposal_id"`
 	Answer           string            `json:"answer"`
 }
 
 func (tx TxVote) ValidateBasic() error {
-	return nil
+	switch tx.Answer {
+	case VOTE_YES, VOTE_NO, VOTE_ABSTAIN, VOTE_NO_WITH_VETO:
+		return nil
+	}
+	return ErrInvalidVoteAnswer()
 }
 
 func NewTxVote(pid string, answer string) sdk.Tx {
@@ -180,3 +402,358 @@ func NewTxVote(pid string, answer string) sdk.Tx {
 }
 
 func (tx TxVote) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+type TxCancelProposal struct {
+	ProposalId       string            `json:"proposal_id"`
+}
+
+func (tx TxCancelProposal) ValidateBasic() error {
+	if tx.ProposalId == "" {
+		return ErrInvalidParameter()
+	}
+	return nil
+}
+
+func NewTxCancelProposal(pid string) sdk.Tx {
+	return TxCancelProposal{
+		pid,
+	}.Wrap()
+}
+
+func (tx TxCancelProposal) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxDelegateVote lets a validator delegate its voting power to another validator,
+// which is counted with the answer of the delegatee on the proposals the delegator doesn't vote on
+type TxDelegateVote struct {
+	Delegatee *common.Address `json:"delegatee"`
+}
+
+func (tx TxDelegateVote) ValidateBasic() error {
+	if tx.Delegatee == nil {
+		return ErrInvalidDelegatee()
+	}
+	return nil
+}
+
+func NewTxDelegateVote(delegatee *common.Address) sdk.Tx {
+	return TxDelegateVote{
+		delegatee,
+	}.Wrap()
+}
+
+func (tx TxDelegateVote) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxRevokeVoteDelegation revokes the vote delegation of the sender
+type TxRevokeVoteDelegation struct{}
+
+func (tx TxRevokeVoteDelegation) ValidateBasic() error {
+	return nil
+}
+
+func NewTxRevokeVoteDelegation() sdk.Tx {
+	return TxRevokeVoteDelegation{}.Wrap()
+}
+
+func (tx TxRevokeVoteDelegation) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxAddValidatorPropose proposes to admit a candidate, so that it can be promoted to a validator
+type TxAddValidatorPropose struct {
+	CandidateAddress  *common.Address `json:"candidate_address"`
+	Reason            string          `json:"reason"`
+	ExpireTimestamp   *int64          `json:"expire_timestamp"`
+	ExpireBlockHeight *int64          `json:"expire_block_height"`
+}
+
+func (tx TxAddValidatorPropose) ValidateBasic() error {
+	if tx.CandidateAddress == nil {
+		return ErrInvalidParameter()
+	}
+	return nil
+}
+
+func (tx TxAddValidatorPropose) Content() *ValidatorContent {
+	return NewValidatorContent(ADD_VALIDATOR_PROPOSAL, *tx.CandidateAddress, tx.Reason)
+}
+
+func NewTxAddValidatorPropose(candidate *common.Address, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+	return TxAddValidatorPropose{
+		candidate,
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxAddValidatorPropose) proposal() *proposeRequest {
+	return &proposeRequest{ADD_VALIDATOR_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, nil, nil}
+}
+
+func (tx TxAddValidatorPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxRemoveValidatorPropose proposes to evict a validator or a candidate
+type TxRemoveValidatorPropose struct {
+	CandidateAddress  *common.Address `json:"candidate_address"`
+	Reason            string          `json:"reason"`
+	ExpireTimestamp   *int64          `json:"expire_timestamp"`
+	ExpireBlockHeight *int64          `json:"expire_block_height"`
+}
+
+func (tx TxRemoveValidatorPropose) ValidateBasic() error {
+	if tx.CandidateAddress == nil {
+		return ErrInvalidParameter()
+	}
+	return nil
+}
+
+func (tx TxRemoveValidatorPropose) Content() *ValidatorContent {
+	return NewValidatorContent(REMOVE_VALIDATOR_PROPOSAL, *tx.CandidateAddress, tx.Reason)
+}
+
+func NewTxRemoveValidatorPropose(candidate *common.Address, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+	return TxRemoveValidatorPropose{
+		candidate,
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxRemoveValidatorPropose) proposal() *proposeRequest {
+	return &proposeRequest{REMOVE_VALIDATOR_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, nil, nil}
+}
+
+func (tx TxRemoveValidatorPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxGrantPropose proposes to pay out an amount to the recipient in tranches, one every interval blocks
+type TxGrantPropose struct {
+	From              *common.Address `json:"grant_from"`
+	To                *common.Address `json:"grant_to"`
+	Amount            string          `json:"amount"`
+	Tranches          int64           `json:"tranches"`
+	Interval          int64           `json:"interval"`
+	Reason            string          `json:"reason"`
+	ExpireTimestamp   *int64          `json:"expire_timestamp"`
+	ExpireBlockHeight *int64          `json:"expire_block_height"`
+}
+
+func (tx TxGrantPropose) ValidateBasic() error {
+	if tx.From == nil || tx.To == nil {
+		return ErrInvalidParameter()
+	}
+	if tx.Tranches < 1 || tx.Interval < 1 {
+		return ErrInvalidGrantSchedule()
+	}
+	return nil
+}
+
+func (tx TxGrantPropose) Content() *GrantContent {
+	return &GrantContent{*tx.From, *tx.To, tx.Amount, tx.Tranches, tx.Interval, tx.Reason}
+}
+
+func NewTxGrantPropose(fromAddr, toAddr *common.Address, amount string, tranches, interval int64, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+	return TxGrantPropose{
+		fromAddr,
+		toAddr,
+		amount,
+		tranches,
+		interval,
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxGrantPropose) proposal() *proposeRequest {
+	return &proposeRequest{GRANT_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, nil, nil}
+}
+
+func (tx TxGrantPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxCancelGrantPropose proposes to cancel an active grant and claw back the amount not paid out yet
+type TxCancelGrantPropose struct {
+	GrantId           string `json:"grant_id"`
+	Reason            string `json:"reason"`
+	ExpireTimestamp   *int64 `json:"expire_timestamp"`
+	ExpireBlockHeight *int64 `json:"expire_block_height"`
+}
+
+func (tx TxCancelGrantPropose) ValidateBasic() error {
+	if tx.GrantId == "" {
+		return ErrInvalidParameter()
+	}
+	return nil
+}
+
+func (tx TxCancelGrantPropose) Content() *CancelGrantContent {
+	return &CancelGrantContent{tx.GrantId, tx.Reason}
+}
+
+func NewTxCancelGrantPropose(grantId, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+	return TxCancelGrantPropose{
+		grantId,
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxCancelGrantPropose) proposal() *proposeRequest {
+	return &proposeRequest{CANCEL_GRANT_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, nil, nil}
+}
+
+func (tx TxCancelGrantPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxContractCallPropose proposes to call a contract from the governance account,
+// data is the hex encoded calldata
+type TxContractCallPropose struct {
+	To                    *common.Address `json:"to"`
+	Value                 string          `json:"value"`
+	Data                  string          `json:"data"`
+	Gas                   uint64          `json:"gas"`
+	Reason                string          `json:"reason"`
+	ExpireTimestamp       *int64          `json:"expire_timestamp"`
+	ExpireBlockHeight     *int64          `json:"expire_block_height"`
+	ExecutionDelay        *int64          `json:"execution_delay,omitempty"`
+	ActivationBlockHeight *int64          `json:"activation_block_height,omitempty"`
+}
+
+func (tx TxContractCallPropose) ValidateBasic() error {
+	if tx.To == nil {
+		return ErrInvalidParameter()
+	}
+	return validateTimelock(tx.ExecutionDelay, tx.ActivationBlockHeight)
+}
+
+func (tx TxContractCallPropose) Content() *ContractCallContent {
+	return &ContractCallContent{*tx.To, tx.Value, tx.Data, tx.Gas, tx.Reason}
+}
+
+func NewTxContractCallPropose(toAddr *common.Address, value, data string, gas uint64, reason string, expireTimestamp, expireBlockHeight, executionDelay, activationBlockHeight *int64) sdk.Tx {
+	return TxContractCallPropose{
+		toAddr,
+		value,
+		data,
+		gas,
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+		executionDelay,
+		activationBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxContractCallPropose) proposal() *proposeRequest {
+	return &proposeRequest{CONTRACT_CALL_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, tx.ExecutionDelay, tx.ActivationBlockHeight}
+}
+
+func (tx TxContractCallPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxEmergencyPausePropose proposes to pause the EVM transactions, or only the calls to the contracts if any is given
+type TxEmergencyPausePropose struct {
+	Contracts         []common.Address `json:"contracts,omitempty"`
+	Duration          int64            `json:"duration"`
+	Reason            string           `json:"reason"`
+	ExpireTimestamp   *int64           `json:"expire_timestamp"`
+	ExpireBlockHeight *int64           `json:"expire_block_height"`
+}
+
+func (tx TxEmergencyPausePropose) ValidateBasic() error {
+	if tx.Duration < 0 {
+		return ErrInvalidPauseDuration()
+	}
+	return nil
+}
+
+func (tx TxEmergencyPausePropose) Content() *PauseContent {
+	return &PauseContent{tx.Contracts, tx.Duration, tx.Reason}
+}
+
+func NewTxEmergencyPausePropose(contracts []common.Address, duration int64, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+	return TxEmergencyPausePropose{
+		contracts,
+		duration,
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxEmergencyPausePropose) proposal() *proposeRequest {
+	return &proposeRequest{EMERGENCY_PAUSE_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, nil, nil}
+}
+
+func (tx TxEmergencyPausePropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxUnpausePropose proposes to lift the active emergency pause
+type TxUnpausePropose struct {
+	Reason            string `json:"reason"`
+	ExpireTimestamp   *int64 `json:"expire_timestamp"`
+	ExpireBlockHeight *int64 `json:"expire_block_height"`
+}
+
+func (tx TxUnpausePropose) ValidateBasic() error {
+	return nil
+}
+
+func (tx TxUnpausePropose) Content() *UnpauseContent {
+	return &UnpauseContent{tx.Reason}
+}
+
+func NewTxUnpausePropose(reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+	return TxUnpausePropose{
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxUnpausePropose) proposal() *proposeRequest {
+	return &proposeRequest{UNPAUSE_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, nil, nil}
+}
+
+func (tx TxUnpausePropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxConsensusParamsPropose proposes to change the tendermint consensus params, the params left nil are unchanged
+type TxConsensusParamsPropose struct {
+	BlockMaxBytes         *int64 `json:"block_max_bytes,omitempty"`
+	BlockMaxTxs           *int64 `json:"block_max_txs,omitempty"`
+	BlockMaxGas           *int64 `json:"block_max_gas,omitempty"`
+	TxMaxBytes            *int64 `json:"tx_max_bytes,omitempty"`
+	TxMaxGas              *int64 `json:"tx_max_gas,omitempty"`
+	BlockPartSizeBytes    *int64 `json:"block_part_size_bytes,omitempty"`
+	Reason                string `json:"reason"`
+	ExpireTimestamp       *int64 `json:"expire_timestamp"`
+	ExpireBlockHeight     *int64 `json:"expire_block_height"`
+	ExecutionDelay        *int64 `json:"execution_delay,omitempty"`
+	ActivationBlockHeight *int64 `json:"activation_block_height,omitempty"`
+}
+
+func (tx TxConsensusParamsPropose) ValidateBasic() error {
+	return validateTimelock(tx.ExecutionDelay, tx.ActivationBlockHeight)
+}
+
+func (tx TxConsensusParamsPropose) Content() *ConsensusParamsContent {
+	return &ConsensusParamsContent{tx.BlockMaxBytes, tx.BlockMaxTxs, tx.BlockMaxGas, tx.TxMaxBytes, tx.TxMaxGas, tx.BlockPartSizeBytes, tx.Reason}
+}
+
+func NewTxConsensusParamsPropose(content *ConsensusParamsContent, expireTimestamp, expireBlockHeight, executionDelay, activationBlockHeight *int64) sdk.Tx {
+	return TxConsensusParamsPropose{
+		content.BlockMaxBytes,
+		content.BlockMaxTxs,
+		content.BlockMaxGas,
+		content.TxMaxBytes,
+		content.TxMaxGas,
+		content.BlockPartSizeBytes,
+		content.Reason,
+		expireTimestamp,
+		expireBlockHeight,
+		executionDelay,
+		activationBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxConsensusParamsPropose) proposal() *proposeRequest {
+	return &proposeRequest{CONSENSUS_PARAMS_PROPOSAL, tx.Content(), tx.ExpireTimestamp, tx.ExpireBlockHeight, tx.ExecutionDelay, tx.ActivationBlockHeight}
+}
+
+func (tx TxConsensusParamsPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
@@ -2,16 +2,17 @@ package governance
 
 import (
 	"encoding/json"
+	"math/big"
 	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/crypto/ripemd160"
 )
 
-const TRANSFER_FUND_PROPOSAL = "transfer_fund"
-const CHANGE_PARAM_PROPOSAL = "change_param"
-const DEPLOY_LIBENI_PROPOSAL = "deploy_libeni"
-const RETIRE_PROGRAM_PROPOSAL = "retire_program"
-const UPGRADE_PROGRAM_PROPOSAL = "upgrade_program"
+const VOTE_YES = "Y"
+const VOTE_NO = "N"
+const VOTE_ABSTAIN = "A"      // counts toward the quorum only
+const VOTE_NO_WITH_VETO = "V" // a no vote which rejects the proposal once the veto threshold is exceeded
 
 type Proposal struct {
 	Id                string
@@ -23,15 +24,75 @@ type Proposal struct {
 	Result            string
 	ResultMsg         string
 	ResultBlockHeight int64
-	Detail            map[string]interface{}
+	Deposit           string
+	// an approved proposal is queued until the execute block height, which is either
+	// the activation block height or the approval height plus the execution delay
+	ExecutionDelay        int64
+	ActivationBlockHeight int64
+	ExecuteBlockHeight    int64
+	// the type specific part, it is encoded as the Detail of the proposal
+	Content ProposalContent `json:"-"`
+}
+
+type proposalJSON struct {
+	*proposalAlias
+	Detail json.RawMessage
+}
+
+type proposalAlias Proposal
+
+func (p Proposal) MarshalJSON() ([]byte, error) {
+	detail, err := json.Marshal(p.Content)
+	if err != nil {
+		return nil, err
+	}
+	alias := proposalAlias(p)
+	return json.Marshal(proposalJSON{&alias, detail})
+}
+
+// UnmarshalJSON decodes the Detail into the content registered for the type of the proposal
+func (p *Proposal) UnmarshalJSON(data []byte) error {
+	pj := proposalJSON{proposalAlias: (*proposalAlias)(p)}
+	if err := json.Unmarshal(data, &pj); err != nil {
+		return err
+	}
+	p.Content = newProposalContent(p.Type)
+	if p.Content == nil || len(pj.Detail) == 0 || string(pj.Detail) == "null" {
+		return nil
+	}
+	return json.Unmarshal(pj.Detail, p.Content)
+}
+
+// detail returns the content as a map, the status of the downloads and retirements is left out
+// as it is updated aside of the proposal
+func (p *Proposal) detail() map[string]interface{} {
+	if p.Content == nil {
+		return nil
+	}
+	b, err := json.Marshal(p.Content)
+	if err != nil {
+		panic(err)
+	}
+	var detail map[string]interface{}
+	if err := json.Unmarshal(b, &detail); err != nil {
+		panic(err)
+	}
+	delete(detail, "status")
+	return detail
+}
+
+// legacyDetailKeys are the keys of the detail hashed before the governance upgrade, for the former proposal types
+var legacyDetailKeys = map[string][]string{
+	TRANSFER_FUND_PROPOSAL:   {"from", "to", "amount", "reason"},
+	CHANGE_PARAM_PROPOSAL:    {"name", "value", "reason"},
+	DEPLOY_LIBENI_PROPOSAL:   {"name", "version", "fileurl", "md5", "reason"},
+	RETIRE_PROGRAM_PROPOSAL:  {"retired_version", "preserved_validators", "reason"},
+	UPGRADE_PROGRAM_PROPOSAL: {"retired_version", "name", "version", "fileurl", "md5", "reason"},
 }
 
 func (p *Proposal) Hash() []byte {
-	var status interface{}
-	if p.Detail != nil {
-		if status = p.Detail["status"]; status != nil {
-			delete(p.Detail, "status")
-		}
+	if keys, ok := legacyDetailKeys[p.Type]; ok && !utils.IsGovernanceUpgraded(getWorkingHeight()) {
+		return p.legacyHash(keys)
 	}
 	pp, err := json.Marshal(struct {
 		Id                string
@@ -43,6 +104,10 @@ func (p *Proposal) Hash() []byte {
 		Result            string
 		ResultMsg         string
 		ResultBlockHeight int64
+		Deposit           string
+		ExecutionDelay        int64
+		ActivationBlockHeight int64
+		ExecuteBlockHeight    int64
 		Detail            map[string]interface{}
 	}{
 		p.Id,
@@ -54,120 +119,57 @@ func (p *Proposal) Hash() []byte {
 		p.Result,
 		p.ResultMsg,
 		p.ResultBlockHeight,
-		p.Detail,
+		p.Deposit,
+		p.ExecutionDelay,
+		p.ActivationBlockHeight,
+		p.ExecuteBlockHeight,
+		p.detail(),
 	})
 	if err != nil {
 		panic(err)
 	}
-	if status != nil {
-		p.Detail["status"] = status
-	}
 	hasher := ripemd160.New()
 	hasher.Write(pp)
 	return hasher.Sum(nil)
 }
 
-func NewTransferFundProposal(id string, proposer *common.Address, blockHeight int64, from *common.Address, to *common.Address, amount string, reason string, expireTimestamp, expireBlockHeight int64) *Proposal {
-	return &Proposal{
-		id,
-		TRANSFER_FUND_PROPOSAL,
-		proposer,
-		blockHeight,
-		expireTimestamp,
-		expireBlockHeight,
-		"",
-		"",
-		0,
-		map[string]interface{}{
-			"from":   from,
-			"to":     to,
-			"amount": amount,
-			"reason": reason,
-		},
-	}
-}
-
-func NewChangeParamProposal(id string, proposer *common.Address, blockHeight int64, name, value, reason string, expireTimestamp, expireBlockHeight int64) *Proposal {
-	return &Proposal{
-		id,
-		CHANGE_PARAM_PROPOSAL,
-		proposer,
-		blockHeight,
-		expireTimestamp,
-		expireBlockHeight,
-		"",
-		"",
-		0,
-		map[string]interface{}{
-			"name":   name,
-			"value":  value,
-			"reason": reason,
-		},
-	}
-}
-
-func NewDeployLibEniProposal(id string, proposer *common.Address, blockHeight int64, name, version, fileurl, md5, reason, status string, expireTimestamp, expireBlockHeight int64) *Proposal {
-	return &Proposal{
-		id,
-		DEPLOY_LIBENI_PROPOSAL,
-		proposer,
-		blockHeight,
-		expireTimestamp,
-		expireBlockHeight,
-		"",
-		"",
-		0,
-		map[string]interface{}{
-			"name":    name,
-			"version": version,
-			"fileurl": fileurl,
-			"md5":     md5,
-			"reason":  reason,
-			"status":  status,
-		},
-	}
-}
-
-func NewRetireProgramProposal(id string, proposer *common.Address, blockHeight int64, retiredVersion, preservedValidators, reason string, expireBlockHeight int64) *Proposal {
-	return &Proposal {
-		id,
-		RETIRE_PROGRAM_PROPOSAL,
-		proposer,
-		blockHeight,
-		0,
-		expireBlockHeight,
-		"",
-		"",
-		0,
-		map[string]interface{}{
-			"retired_version": retiredVersion,
-			"preserved_validators": preservedValidators,
-			"reason": reason,
-			"status": "",
-		},
-	}
-}
-
-func NewUpgradeProgramProposal(id string, proposer *common.Address, blockHeight int64, retiredVersion, name, version, fileurl, md5, reason string, expireBlockHeight int64) *Proposal {
-	return &Proposal{
-		id,
-		UPGRADE_PROGRAM_PROPOSAL,
-		proposer,
-		blockHeight,
-		0,
-		expireBlockHeight,
-		"",
-		"",
-		0,
-		map[string]interface{}{
-			"retired_version": retiredVersion,
-			"name":            name,
-			"version":         version,
-			"fileurl":         fileurl,
-			"md5":             md5,
-			"reason":          reason,
-		},
+// legacyHash hashes the proposal the way it was hashed before the governance upgrade,
+// so that the proposals rewritten before the upgrade height keep the hash of the former releases
+func (p *Proposal) legacyHash(keys []string) []byte {
+	detail := p.detail()
+	legacyDetail := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		legacyDetail[key] = detail[key]
+	}
+	pp, err := json.Marshal(struct {
+		Id                string
+		Type              string
+		Proposer          *common.Address
+		BlockHeight       int64
+		ExpireTimestamp   int64
+		ExpireBlockHeight int64
+		Result            string
+		ResultMsg         string
+		ResultBlockHeight int64
+		Detail            map[string]interface{}
+	}{
+		p.Id,
+		p.Type,
+		p.Proposer,
+		p.BlockHeight,
+		p.ExpireTimestamp,
+		p.ExpireBlockHeight,
+		p.Result,
+		p.ResultMsg,
+		p.ResultBlockHeight,
+		legacyDetail,
+	})
+	if err != nil {
+		panic(err)
 	}
+	hasher := ripemd160.New()
+	hasher.Write(pp)
+	return hasher.Sum(nil)
 }
 
 type Vote struct {
@@ -193,3 +195,260 @@ func NewVote(proposalId string, voter common.Address, blockHeight int64, answer
 		answer,
 	}
 }
+
+// SnapshotValidator is a validator with its voting power when a proposal is created,
+// the votes on the proposal are tallied against the snapshot.
+// Delegatee is the validator it delegated its vote to at the time, empty if none.
+type SnapshotValidator struct {
+	ProposalId   string
+	OwnerAddress string
+	VotingPower  int64
+	Delegatee    string
+}
+
+func (v *SnapshotValidator) Hash() []byte {
+	var excludedFields []string
+	bs := types.Hash(v, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
+// VoteDelegation is the voting power of the delegator counted with the vote of the delegatee,
+// when the delegator doesn't vote itself
+type VoteDelegation struct {
+	Delegator   common.Address
+	Delegatee   common.Address
+	BlockHeight int64
+}
+
+func (d *VoteDelegation) Hash() []byte {
+	var excludedFields []string
+	bs := types.Hash(d, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
+const (
+	EVENT_PROPOSAL_CREATED = "created"
+	EVENT_VOTE_CAST        = "vote_cast"
+	EVENT_VOTE_CHANGED     = "vote_changed"
+	EVENT_PROPOSAL_QUEUED  = "queued"
+	EVENT_RETIRE_STATUS    = "retire_status"
+	EVENT_GRANT_PAID       = "grant_paid"
+	EVENT_GRANT_CLAWBACK   = "grant_clawback"
+	EVENT_PAUSE_LIFTED     = "pause_lifted"
+	EVENT_PAUSE_EXPIRED    = "pause_expired"
+	EVENT_CONSENSUS_PARAMS = "consensus_params"
+	// the decision of a proposal is recorded with its lower-cased result as the type, e.g. approved
+)
+
+// Event is an entry of the append-only governance event log.
+// Each event is chained to the previous one of the log by including its hash.
+type Event struct {
+	Id          int64
+	ProposalId  string
+	Type        string
+	Actor       string
+	Detail      string
+	BlockHeight int64
+	PrevHash    string
+	Hash        string
+}
+
+func (e *Event) ComputeHash() []byte {
+	excludedFields := []string{"Id", "Hash"}
+	bs := types.Hash(e, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
+const (
+	PENDING_PROPOSAL_STATUS = "Pending"
+
+	DEFAULT_PROPOSALS_PER_PAGE = 30
+	MAX_PROPOSALS_PER_PAGE     = 100
+)
+
+// ProposalFilter selects the proposals returned by a query, empty fields match any proposal.
+// Status is either Pending or the result of a proposal, e.g. Approved.
+type ProposalFilter struct {
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	Proposer   string `json:"proposer"`
+	FromHeight int64  `json:"from_height"`
+	ToHeight   int64  `json:"to_height"`
+	Page       int    `json:"page"`
+	PerPage    int    `json:"per_page"`
+}
+
+// Pagination returns the requested page, starting from 1, and its size within the allowed bounds
+func (f ProposalFilter) Pagination() (page, perPage int) {
+	page, perPage = f.Page, f.PerPage
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = DEFAULT_PROPOSALS_PER_PAGE
+	} else if perPage > MAX_PROPOSALS_PER_PAGE {
+		perPage = MAX_PROPOSALS_PER_PAGE
+	}
+	return
+}
+
+// ProposalPage is a page of the proposals matching a filter, Total counts all of them
+type ProposalPage struct {
+	Total     int64       `json:"total"`
+	Page      int         `json:"page"`
+	PerPage   int         `json:"per_page"`
+	Proposals []*Proposal `json:"proposals"`
+}
+
+// Tally is the voting power behind each answer of a proposal,
+// counted over the validators at BlockHeight
+type Tally struct {
+	ProposalId   string
+	YesPower     int64
+	NoPower      int64
+	AbstainPower int64
+	VetoPower    int64
+	TotalPower   int64
+	Turnout      string
+	BlockHeight  int64
+}
+
+func NewTally(proposalId string, yesPower, noPower, abstainPower, vetoPower, totalPower, blockHeight int64) *Tally {
+	t := &Tally{
+		ProposalId:   proposalId,
+		YesPower:     yesPower,
+		NoPower:      noPower,
+		AbstainPower: abstainPower,
+		VetoPower:    vetoPower,
+		TotalPower:   totalPower,
+		BlockHeight:  blockHeight,
+	}
+	t.Turnout = t.turnout()
+	return t
+}
+
+// VotedPower is the voting power of the validators who have voted, abstentions included
+func (t *Tally) VotedPower() int64 {
+	return t.YesPower + t.NoPower + t.AbstainPower + t.VetoPower
+}
+
+func (t *Tally) turnout() string {
+	if t.TotalPower == 0 {
+		return "0"
+	}
+	return big.NewRat(t.VotedPower(), t.TotalPower).FloatString(4)
+}
+
+const (
+	GRANT_STATUS_ACTIVE    = "Active"
+	GRANT_STATUS_COMPLETED = "Completed"
+	GRANT_STATUS_CANCELLED = "Cancelled"
+)
+
+const (
+	PAUSE_STATUS_ACTIVE  = "Active"
+	PAUSE_STATUS_LIFTED  = "Lifted"
+	PAUSE_STATUS_EXPIRED = "Expired"
+)
+
+// status of the update of an approved consensus_params proposal
+const (
+	CONSENSUS_PARAMS_STATUS_PENDING = "Pending"
+	CONSENSUS_PARAMS_STATUS_APPLIED = "Applied"
+	CONSENSUS_PARAMS_STATUS_FAILED  = "Failed"
+)
+
+// Pause is an emergency pause of the EVM transactions, started by an approved emergency_pause proposal.
+// It lasts from StartBlockHeight until EndBlockHeight, unless it is lifted earlier by an unpause proposal
+// or replaced by another emergency pause.
+type Pause struct {
+	ProposalId string
+	// only the calls to these contracts are paused, all the EVM transactions if it is empty
+	Contracts        []common.Address
+	StartBlockHeight int64
+	EndBlockHeight   int64
+	Status           string
+	// ID of the proposal the pause was lifted or replaced by
+	LiftedBy string
+	// height of the start, the lifting or the expiration
+	BlockHeight int64
+}
+
+func (p *Pause) Hash() []byte {
+	var excludedFields []string
+	bs := types.Hash(p, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
+// Covers returns whether an EVM transaction to the address is paused at the block height,
+// to is nil for a contract creation
+func (p *Pause) Covers(to *common.Address, blockHeight int64) bool {
+	if blockHeight < p.StartBlockHeight || blockHeight >= p.EndBlockHeight {
+		return false
+	}
+	if len(p.Contracts) == 0 {
+		return true
+	}
+	if to == nil {
+		return false
+	}
+	for _, c := range p.Contracts {
+		if c == *to {
+			return true
+		}
+	}
+	return false
+}
+
+// Grant is the payout state of an approved grant proposal. The amount is escrowed in the governance hold account,
+// and released to the recipient in Tranches, one every Interval blocks starting from NextBlockHeight.
+type Grant struct {
+	ProposalId string
+	// the account the amount was escrowed from, the remaining amount is clawed back to it when the grant is cancelled
+	From            common.Address
+	To              common.Address
+	Amount          string
+	Paid            string
+	Tranches        int64
+	TranchesPaid    int64
+	Interval        int64
+	NextBlockHeight int64
+	Status          string
+	// height of the last payout or of the cancellation
+	BlockHeight int64
+}
+
+func (g *Grant) Hash() []byte {
+	var excludedFields []string
+	bs := types.Hash(g, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
+// Remaining is the amount still escrowed for the grant
+func (g *Grant) Remaining() *big.Int {
+	amount, _ := new(big.Int).SetString(g.Amount, 10)
+	paid, _ := new(big.Int).SetString(g.Paid, 10)
+	if amount == nil || paid == nil {
+		return big.NewInt(0)
+	}
+	return amount.Sub(amount, paid)
+}
+
+// nextTranche is the amount released by the next payout, the last tranche includes the remainder of the division
+func (g *Grant) nextTranche() *big.Int {
+	if g.TranchesPaid+1 >= g.Tranches {
+		return g.Remaining()
+	}
+	amount, _ := new(big.Int).SetString(g.Amount, 10)
+	return amount.Div(amount, big.NewInt(g.Tranches))
+}
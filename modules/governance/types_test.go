@@ -0,0 +1,124 @@
+package governance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/vangjvn/devchain/utils"
+	"golang.org/x/crypto/ripemd160"
+)
+
+func TestGrantTranches(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		name                   string
+		amount, paid           string
+		tranches, tranchesPaid int64
+		remaining, next        int64
+	}{
+		{"first of even tranches", "300", "0", 3, 0, 300, 100},
+		{"middle of even tranches", "300", "100", 3, 1, 200, 100},
+		{"last of even tranches", "300", "200", 3, 2, 100, 100},
+		{"first of uneven tranches", "100", "0", 3, 0, 100, 33},
+		{"last tranche with the remainder", "100", "66", 3, 2, 34, 34},
+		{"single tranche", "100", "0", 1, 0, 100, 100},
+		{"paid off", "100", "100", 3, 3, 0, 0},
+		{"invalid amount", "abc", "0", 3, 2, 0, 0},
+	}
+
+	for _, c := range cases {
+		g := &Grant{Amount: c.amount, Paid: c.paid, Tranches: c.tranches, TranchesPaid: c.tranchesPaid}
+		assert.Equal(c.remaining, g.Remaining().Int64(), c.name)
+		assert.Equal(c.next, g.nextTranche().Int64(), c.name)
+	}
+}
+
+func TestPauseCovers(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	all := &Pause{StartBlockHeight: 10, EndBlockHeight: 20}
+	some := &Pause{Contracts: []common.Address{a}, StartBlockHeight: 10, EndBlockHeight: 20}
+
+	cases := []struct {
+		name        string
+		pause       *Pause
+		to          *common.Address
+		blockHeight int64
+		expected    bool
+	}{
+		{"before the start", all, &a, 9, false},
+		{"at the start", all, &a, 10, true},
+		{"before the end", all, &a, 19, true},
+		{"at the end", all, &a, 20, false},
+		{"contract creation paused by all", all, nil, 15, true},
+		{"listed contract", some, &a, 15, true},
+		{"other contract", some, &b, 15, false},
+		{"contract creation", some, nil, 15, false},
+		{"listed contract after the end", some, &a, 20, false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(c.expected, c.pause.Covers(c.to, c.blockHeight), c.name)
+	}
+}
+
+// baselineHash hashes a proposal with the layout of the releases before the governance upgrade
+func baselineHash(t *testing.T, p *Proposal, detail map[string]interface{}) []byte {
+	pp, err := json.Marshal(struct {
+		Id                string
+		Type              string
+		Proposer          *common.Address
+		BlockHeight       int64
+		ExpireTimestamp   int64
+		ExpireBlockHeight int64
+		Result            string
+		ResultMsg         string
+		ResultBlockHeight int64
+		Detail            map[string]interface{}
+	}{p.Id, p.Type, p.Proposer, p.BlockHeight, p.ExpireTimestamp, p.ExpireBlockHeight, p.Result, p.ResultMsg, p.ResultBlockHeight, detail})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasher := ripemd160.New()
+	hasher.Write(pp)
+	return hasher.Sum(nil)
+}
+
+func TestProposalHashBeforeUpgrade(t *testing.T) {
+	defer utils.SetGovernanceUpgradeHeight(utils.PrivateChain)
+
+	proposer, from, to := common.HexToAddress("0x1"), common.HexToAddress("0x2"), common.HexToAddress("0x3")
+	transfer := &Proposal{
+		Id:                "0xabc",
+		Type:              TRANSFER_FUND_PROPOSAL,
+		Proposer:          &proposer,
+		BlockHeight:       10,
+		ExpireBlockHeight: 100,
+		Result:            "Approved",
+		ResultBlockHeight: 50,
+		Deposit:           "1000",
+		ExecutionDelay:    20,
+		Content:           &TransferFundContent{From: from, To: to, Amount: "1", Reason: "test"},
+	}
+	changeParam := &Proposal{
+		Id:       "0xdef",
+		Type:     CHANGE_PARAM_PROPOSAL,
+		Proposer: &proposer,
+		Content:  &ChangeParamContent{Name: "gas_price", Value: "1", Params: []ParamChange{{"gas_price", "1"}}, Reason: "test"},
+	}
+
+	t.Run("former layout before the upgrade height", func(t *testing.T) {
+		utils.SetGovernanceUpgradeHeight(utils.MainNet)
+		assert.Equal(t, baselineHash(t, transfer, map[string]interface{}{"from": &from, "to": &to, "amount": "1", "reason": "test"}), transfer.Hash())
+		assert.Equal(t, baselineHash(t, changeParam, map[string]interface{}{"name": "gas_price", "value": "1", "reason": "test"}), changeParam.Hash())
+	})
+
+	t.Run("new layout from the upgrade height", func(t *testing.T) {
+		utils.SetGovernanceUpgradeHeight(utils.PrivateChain)
+		assert.NotEqual(t, baselineHash(t, transfer, map[string]interface{}{"from": &from, "to": &to, "amount": "1", "reason": "test"}), transfer.Hash())
+	})
+}
@@ -15,6 +15,11 @@ The stake/query/validator is to query the current stake status of the validator.
 
 * Validator address
 
+The stake/query/validator-verification is to query the pending sign offs of the foundation committee
+on the verification of a validator. Not signed.
+
+* Validator address
+
 The stake/query/delegator is to query the current stake status of a delegator. Not signed.
 
 * Delegator address
@@ -38,6 +43,12 @@ var (
 		RunE:  cmdQueryValidators,
 		Short: "Query a list of all current validators and validator candidates",
 	}
+
+	CmdQueryValidatorVerification = &cobra.Command{
+		Use:   "validator-verification",
+		RunE:  cmdQueryValidatorVerification,
+		Short: "Query the pending sign offs of the foundation committee on the verification of a validator",
+	}
 )
 
 func init() {
@@ -46,6 +57,7 @@ func init() {
 	fsAddr.String(FlagAddress, "", "account address")
 
 	CmdQueryValidator.Flags().AddFlagSet(fsAddr)
+	CmdQueryValidatorVerification.Flags().AddFlagSet(fsAddr)
 }
 
 func cmdQueryValidators(cmd *cobra.Command, args []string) error {
@@ -69,6 +81,19 @@ func cmdQueryValidator(cmd *cobra.Command, args []string) error {
 	return Foutput(b)
 }
 
+func cmdQueryValidatorVerification(cmd *cobra.Command, args []string) error {
+	address := viper.GetString(FlagAddress)
+	if address == "" {
+		return fmt.Errorf("please enter validator address using --address")
+	}
+
+	b, err := Get("/validator/verification", []byte(address))
+	if err != nil {
+		return err
+	}
+	return Foutput(b)
+}
+
 func Get(path string, params []byte) ([]byte, error) {
 	node := commands.GetNode()
 	resp, err := node.ABCIQuery(path, params)
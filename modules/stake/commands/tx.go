@@ -77,7 +77,7 @@ var (
 	}
 	CmdVerifyCandidacy = &cobra.Command{
 		Use:   "verify-candidacy",
-		Short: "Allows the foundation to verify a validator/candidate's information",
+		Short: "Allows a foundation committee member to sign off the verification of a validator/candidate's information",
 		RunE:  cmdVerifyCandidacy,
 	}
 	CmdActivateCandidacy = &cobra.Command{
@@ -135,7 +135,7 @@ func getCandidatesInternal(cond map[string]interface{}) (candidates Candidates)
 	defer txWrapper.Commit()
 
 	clause, params := buildQueryClause(cond)
-	rows, err := txWrapper.tx.Query("select id, pub_key, address, voting_power, name, website, location, profile, email, verified, active, block_height, state, created_at from candidates"+clause, params...)
+	rows, err := txWrapper.tx.Query("select id, pub_key, address, voting_power, name, website, location, profile, email, verified, active, admitted, block_height, state, created_at from candidates"+clause, params...)
 	if err != nil {
 		panic(err)
 	}
@@ -146,9 +146,9 @@ func getCandidatesInternal(cond map[string]interface{}) (candidates Candidates)
 
 func composeCandidateResults(rows *sql.Rows) (candidates Candidates) {
 	for rows.Next() {
-		var pubKey, address, name, website, location, profile, email, state, verified, active string
+		var pubKey, address, name, website, location, profile, email, state, verified, active, admitted string
 		var id, votingPower, blockHeight, createdAt int64
-		err := rows.Scan(&id, &pubKey, &address, &votingPower, &name, &website, &location, &profile, &email, &verified, &active, &blockHeight, &state, &createdAt)
+		err := rows.Scan(&id, &pubKey, &address, &votingPower, &name, &website, &location, &profile, &email, &verified, &active, &admitted, &blockHeight, &state, &createdAt)
 		if err != nil {
 			panic(err)
 		}
@@ -169,6 +169,7 @@ func composeCandidateResults(rows *sql.Rows) (candidates Candidates) {
 			Verified:     verified,
 			CreatedAt:    createdAt,
 			Active:       active,
+			Admitted:     admitted,
 			BlockHeight:  blockHeight,
 			State:        state,
 		}
@@ -185,7 +186,7 @@ func SaveCandidate(candidate *Candidate) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
 
-	stmt, err := txWrapper.tx.Prepare("insert into candidates(pub_key, address, voting_power, name, website, location, profile, email, verified, active, hash, block_height, state, created_at) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	stmt, err := txWrapper.tx.Prepare("insert into candidates(pub_key, address, voting_power, name, website, location, profile, email, verified, active, admitted, hash, block_height, state, created_at) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		panic(err)
 	}
@@ -203,6 +204,7 @@ func SaveCandidate(candidate *Candidate) {
 		candidate.Description.Email,
 		candidate.Verified,
 		candidate.Active,
+		candidate.Admitted,
 		common.Bytes2Hex(candidate.Hash()),
 		candidate.BlockHeight,
 		candidate.State,
@@ -217,7 +219,7 @@ func updateCandidate(candidate *Candidate) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
 
-	stmt, err := txWrapper.tx.Prepare("update candidates set address = ?, voting_power = ?, name =?, website = ?, location = ?, profile = ?, email = ?, verified = ?, active = ?, hash = ?, state = ?, pub_key = ? where id = ?")
+	stmt, err := txWrapper.tx.Prepare("update candidates set address = ?, voting_power = ?, name =?, website = ?, location = ?, profile = ?, email = ?, verified = ?, active = ?, admitted = ?, hash = ?, state = ?, pub_key = ? where id = ?")
 	if err != nil {
 		panic(err)
 	}
@@ -234,6 +236,7 @@ func updateCandidate(candidate *Candidate) {
 		candidate.Description.Email,
 		candidate.Verified,
 		candidate.Active,
+		candidate.Admitted,
 		common.Bytes2Hex(candidate.Hash()),
 		candidate.State,
 		types.PubKeyString(candidate.PubKey),
@@ -352,3 +355,77 @@ func updateCandidateAccountUpdateRequest(req *CandidateAccountUpdateRequest) {
 		panic(err)
 	}
 }
+
+func saveVerificationSignOff(signOff *VerificationSignOff) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("insert into candidate_verification_sign_offs(candidate_address, verifier, verified, block_height, hash) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(
+		signOff.CandidateAddress.String(),
+		signOff.Verifier.String(),
+		signOff.Verified,
+		signOff.BlockHeight,
+		common.Bytes2Hex(signOff.Hash()),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func getVerificationSignOffs(candidateAddress common.Address) []*VerificationSignOff {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	return getVerificationSignOffsInternal(txWrapper.tx, candidateAddress)
+}
+
+func getVerificationSignOffsInternal(tx *sql.Tx, candidateAddress common.Address) (signOffs []*VerificationSignOff) {
+	rows, err := tx.Query("select candidate_address, verifier, verified, block_height from candidate_verification_sign_offs where candidate_address = ?", candidateAddress.String())
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var candidate, verifier, verified string
+		var blockHeight int64
+		err := rows.Scan(&candidate, &verifier, &verified, &blockHeight)
+		if err != nil {
+			panic(err)
+		}
+
+		signOffs = append(signOffs, &VerificationSignOff{
+			CandidateAddress: common.HexToAddress(candidate),
+			Verifier:         common.HexToAddress(verifier),
+			Verified:         verified,
+			BlockHeight:      blockHeight,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+	return
+}
+
+func deleteVerificationSignOffs(candidateAddress common.Address) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("delete from candidate_verification_sign_offs where candidate_address = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(candidateAddress.String())
+	if err != nil {
+		panic(err)
+	}
+}
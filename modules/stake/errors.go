@@ -20,6 +20,7 @@ var (
 	errPubKeyAlreadyDeclared              = fmt.Errorf("PubKey has been declared")
 	errCandidateAlreadyActivated          = fmt.Errorf("Candidate has been activated")
 	errCandidateAlreadyDeactivated        = fmt.Errorf("Candidate has been deactivated")
+	errCandidateEvicted                   = fmt.Errorf("Candidate has been evicted by governance")
 	errBadRequest                         = fmt.Errorf("Bad request")
 
 	invalidInput = errors.CodeTypeBaseInvalidInput
@@ -67,3 +68,7 @@ func ErrCandidateAlreadyActivated() error {
 func ErrCandidateAlreadyDeactivated() error {
 	return errors.WithCode(errCandidateAlreadyDeactivated, errors.CodeTypeBaseInvalidOutput)
 }
+
+func ErrCandidateEvicted() error {
+	return errors.WithCode(errCandidateEvicted, errors.CodeTypeBaseInvalidOutput)
+}
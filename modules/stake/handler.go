@@ -263,8 +263,8 @@ func (c check) verifyCandidacy(tx TxVerifyCandidacy) error {
 		return ErrBadValidatorAddr()
 	}
 
-	// check to see if the request was initiated by a special account
-	if c.sender != common.HexToAddress(utils.GetParams().FoundationAddress) {
+	// check to see if the request was initiated by a member of the foundation committee
+	if !c.params.IsFoundationCommitteeMember(c.sender) {
 		return ErrVerificationDisallowed()
 	}
 
@@ -282,6 +282,11 @@ func (c check) activateCandidacy(tx TxActivateCandidacy) error {
 		return ErrCandidateAlreadyActivated()
 	}
 
+	// an evicted candidate can't come back before an add_validator proposal is approved
+	if candidate.IsEvicted() {
+		return ErrCandidateEvicted()
+	}
+
 	return nil
 }
 
@@ -376,6 +381,7 @@ func (d deliver) declareCandidacy(tx TxDeclareCandidacy, gasFee sdk.Int) error {
 		Description:  tx.Description,
 		Verified:     "N",
 		Active:       "Y",
+		Admitted:     "N",
 		BlockHeight:  d.ctx.BlockHeight(),
 		State:        "Candidate",
 	}
@@ -405,6 +411,7 @@ func (d deliver) declareGenesisCandidacy(tx TxDeclareCandidacy, val types.Genesi
 		Description:  tx.Description,
 		Verified:     "N",
 		Active:       "Y",
+		Admitted:     "Y",
 		BlockHeight:  d.ctx.BlockHeight(),
 		State:        "Validator",
 	}
@@ -486,14 +493,29 @@ func (d deliver) withdrawCandidacy(tx TxWithdrawCandidacy) error {
 }
 
 func (d deliver) verifyCandidacy(tx TxVerifyCandidacy) error {
-	// verify candidacy
-	candidate := GetCandidateByAddress(tx.CandidateAddress)
+	verified := "N"
 	if tx.Verified {
-		candidate.Verified = "Y"
-	} else {
-		candidate.Verified = "N"
+		verified = "Y"
+	}
+	// a later sign off of the same member replaces its earlier one
+	saveVerificationSignOff(&VerificationSignOff{tx.CandidateAddress, d.sender, verified, d.ctx.BlockHeight()})
+
+	// only the sign offs of the current committee members are counted
+	signOffs := 0
+	for _, so := range getVerificationSignOffs(tx.CandidateAddress) {
+		if so.Verified == verified && d.params.IsFoundationCommitteeMember(so.Verifier) {
+			signOffs++
+		}
 	}
+	if signOffs < d.params.FoundationCommitteeThreshold {
+		return nil
+	}
+
+	// verify candidacy
+	candidate := GetCandidateByAddress(tx.CandidateAddress)
+	candidate.Verified = verified
 	updateCandidate(candidate)
+	deleteVerificationSignOffs(tx.CandidateAddress)
 	return nil
 }
 
@@ -521,6 +543,33 @@ func (d deliver) deactivateCandidacy(tx TxDeactivateCandidacy) error {
 	return nil
 }
 
+// AdmitCandidate records the approval of the candidate by governance,
+// it is required for the promotion to a validator if the validator_admission_required param is set, and lifts an earlier eviction
+func AdmitCandidate(address common.Address) error {
+	candidate := GetCandidateByAddress(address)
+	if candidate == nil {
+		return ErrBadValidatorAddr()
+	}
+
+	candidate.Admitted = "Y"
+	updateCandidate(candidate)
+	return nil
+}
+
+// EvictCandidate deactivates the candidate and records its eviction, which keeps it from activating its candidacy
+// again until it is admitted by governance. It is removed from the validator set when the set is updated at the end of the next block.
+func EvictCandidate(address common.Address) error {
+	candidate := GetCandidateByAddress(address)
+	if candidate == nil {
+		return ErrBadValidatorAddr()
+	}
+
+	candidate.Active = "N"
+	candidate.Admitted = "E"
+	updateCandidate(candidate)
+	return nil
+}
+
 func (d deliver) updateCandidateAccount(tx TxUpdateCandidacyAccount, gasFee sdk.Int) (int64, error) {
 	// check if the delegator has sufficient funds
 	if err := checkBalance(d.ctx.EthappState(), d.sender, gasFee); err != nil {
@@ -0,0 +1,67 @@
+package stake
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+func TestVerifyCandidacyByCommittee(t *testing.T) {
+	defer setupTestDb(t)()
+
+	var (
+		alice    = common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+		bob      = common.HexToAddress("0x77beb894fc9b0ed41231e51f128a347043960a9d")
+		carol    = common.HexToAddress("0x84f444c0405c761f5a2b6ac6ee6fb4ebc7a8c4b3")
+		outsider = common.HexToAddress("0x283ed77f880d87dbdef2c2bbed8e7a2ddb9d1e80")
+		owner    = common.HexToAddress("0x1c4ed8b1ae2b8f5b0d1e6a0e0d4b4b0de1d1e8f5")
+	)
+	SaveCandidate(&Candidate{
+		PubKey:       types.PubKey{PubKey: ed25519.GenPrivKey().PubKey()},
+		OwnerAddress: owner.String(),
+		Verified:     "N",
+		Active:       "Y",
+		Admitted:     "N",
+	})
+
+	params := utils.DefaultParams()
+	params.FoundationCommittee = alice.String() + "," + bob.String() + "," + carol.String()
+	params.FoundationCommitteeThreshold = 2
+
+	// the sign offs in order, and the verified status of the candidate after each of them
+	steps := []struct {
+		sender   common.Address
+		verified bool
+		status   string
+	}{
+		{alice, true, "N"},
+		{alice, true, "N"}, // the same member signing off again doesn't count twice
+		{bob, false, "N"},  // a sign off on the other status doesn't count either
+		{carol, true, "Y"},
+		{alice, false, "Y"}, // the sign offs are cleared once the status is changed
+		{bob, false, "N"},
+	}
+
+	for i, s := range steps {
+		ctx := types.NewContext("test", int64(i+1), 0, nil)
+		tx := TxVerifyCandidacy{CandidateAddress: owner, Verified: s.verified}
+		if err := (check{sender: s.sender, params: params, ctx: ctx}).verifyCandidacy(tx); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if err := (deliver{sender: s.sender, params: params, ctx: ctx}).verifyCandidacy(tx); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if got := GetCandidateByAddress(owner).Verified; got != s.status {
+			t.Errorf("step %d: verified = %s, want %s", i, got, s.status)
+		}
+	}
+
+	tx := TxVerifyCandidacy{CandidateAddress: owner, Verified: true}
+	if err := (check{sender: outsider, params: params}).verifyCandidacy(tx); err == nil {
+		t.Error("the sign off of a non member should be disallowed")
+	}
+}
@@ -0,0 +1,90 @@
+package stake
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/vangjvn/devchain/sdk"
+	"github.com/vangjvn/devchain/sdk/state"
+	"github.com/vangjvn/devchain/types"
+)
+
+// nolint
+const stakeModuleName = "stake"
+
+// Module plugs the stake module into the application
+type Module struct{}
+
+func NewModule() Module {
+	return Module{}
+}
+
+func (Module) Name() string {
+	return stakeModuleName
+}
+
+// InitGenesis declares the genesis validators
+func (Module) InitGenesis(store state.SimpleDB, genDoc *types.GenesisDoc) error {
+	for _, val := range genDoc.Validators {
+		if err := SetGenesisValidator(val, store); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (Module) Load() {
+}
+
+func (Module) AfterCommit() {
+}
+
+func (Module) CheckTx(ctx types.Context, store state.SimpleDB, tx sdk.Tx) (sdk.CheckResult, error) {
+	return CheckTx(ctx, store, tx)
+}
+
+func (Module) DeliverTx(ctx types.Context, store state.SimpleDB, tx sdk.Tx, hash []byte) (sdk.DeliverResult, error) {
+	return DeliverTx(ctx, store, tx, hash)
+}
+
+func (Module) CheckEthTx(to *common.Address, blockHeight int64) error {
+	return nil
+}
+
+func (Module) QueryRoutes() map[string]sdk.QueryHandler {
+	return map[string]sdk.QueryHandler{
+		"/validators": func(data []byte) ([]byte, error) {
+			return json.Marshal(QueryCandidates())
+		},
+		"/validator": func(data []byte) ([]byte, error) {
+			candidate := QueryCandidateByAddress(common.HexToAddress(string(data)))
+			if candidate == nil {
+				return []byte{}, nil
+			}
+			return json.Marshal(candidate)
+		},
+		"/validator/verification": func(data []byte) ([]byte, error) {
+			return json.Marshal(QueryVerificationSignOffs(common.HexToAddress(string(data))))
+		},
+	}
+}
+
+func (Module) BeginBlock(ctx types.Context, store state.SimpleDB, req abci.RequestBeginBlock) {
+}
+
+// EndBlock updates the validator set
+func (Module) EndBlock(ctx types.Context, store state.SimpleDB, req abci.RequestEndBlock) ([]abci.Validator, *abci.ConsensusParams, error) {
+	diff, err := UpdateValidatorSet(store)
+	return diff, nil, err
+}
+
+func (Module) SetDeliverSqlTx(tx *sql.Tx) {
+	SetDeliverSqlTx(tx)
+}
+
+func (Module) ResetDeliverSqlTx() {
+	ResetDeliverSqlTx()
+}
@@ -26,7 +26,7 @@ func QueryCandidateByAddress(address common.Address) *Candidate {
 
 func queryCandidates(db *sql.DB, cond map[string]interface{}) (candidates Candidates) {
 	clause, params := buildQueryClause(cond)
-	rows, err := db.Query("select id, pub_key, address, voting_power, name, website, location, profile, email, verified, active, block_height, state, created_at from candidates"+clause, params...)
+	rows, err := db.Query("select id, pub_key, address, voting_power, name, website, location, profile, email, verified, active, admitted, block_height, state, created_at from candidates"+clause, params...)
 	if err != nil {
 		panic(err)
 	}
@@ -34,3 +34,14 @@ func queryCandidates(db *sql.DB, cond map[string]interface{}) (candidates Candid
 	candidates = composeCandidateResults(rows)
 	return
 }
+
+// QueryVerificationSignOffs returns the pending sign offs of the foundation committee on the candidate
+func QueryVerificationSignOffs(candidateAddress common.Address) []*VerificationSignOff {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	return getVerificationSignOffsInternal(tx, candidateAddress)
+}
@@ -34,6 +34,7 @@ type Candidate struct {
 	Description           Description  `json:"description"`
 	Verified              string       `json:"verified"`
 	Active                string       `json:"active"`
+	Admitted              string       `json:"admitted"`                // Y once admitted by a governance proposal, E once evicted by one
 	BlockHeight           int64        `json:"block_height"`
 	State                 string       `json:"state"`
 }
@@ -68,6 +69,15 @@ func (c Candidate) IsActive() bool {
 	return c.Active == "Y"
 }
 
+func (c Candidate) IsAdmitted() bool {
+	return c.Admitted == "Y"
+}
+
+// IsEvicted returns whether the candidate has been evicted by governance and not admitted again since
+func (c Candidate) IsEvicted() bool {
+	return c.Admitted == "E"
+}
+
 // Validator is one of the top Candidates
 type Validator Candidate
 
@@ -122,6 +132,9 @@ func (cs Candidates) updateVotingPower(updates PubKeyUpdates) Candidates {
 		if c.Active == "N" {
 			c.VotingPower = 0
 			c.State = "Candidate"
+		} else if c.VotingPower == 0 && !c.IsAdmitted() && utils.GetParams().ValidatorAdmissionRequired {
+			// the candidate can't be promoted before its admission is approved by governance
+			c.State = "Candidate"
 		} else {
 			c.VotingPower = c.CalcVotingPower()
 			c.State = "Validator"
@@ -301,6 +314,23 @@ func (c *CandidateAccountUpdateRequest) Hash() []byte {
 	return hasher.Sum(nil)
 }
 
+// VerificationSignOff is the sign off of a foundation committee member on the verified status of a candidate,
+// the status is changed once enough distinct members have signed off the same status
+type VerificationSignOff struct {
+	CandidateAddress common.Address `json:"candidate_address"`
+	Verifier         common.Address `json:"verifier"`
+	Verified         string         `json:"verified"`
+	BlockHeight      int64          `json:"block_height"`
+}
+
+func (s *VerificationSignOff) Hash() []byte {
+	var excludedFields []string
+	bs := types.Hash(s, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
 type PubKeyUpdate struct {
 	OldPubKey   types.PubKey `json:"old_pub_key"`
 	NewPubKey   types.PubKey `json:"new_pub_key"`
@@ -0,0 +1,83 @@
+package stake
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/vangjvn/devchain/sdk/dbm"
+	"github.com/vangjvn/devchain/types"
+	"github.com/vangjvn/devchain/utils"
+)
+
+// testSchema is the part of the devchain database used by the tests of the module
+const testSchema = `
+	create table candidates(id integer not null primary key autoincrement, address text not null, pub_key text not null, voting_power integer default 0, name text not null default '', website text not null default '', location text not null default '', email text not null default '', profile text not null default '', verified text not null default 'N', active text not null default 'Y', admitted text not null default 'N', state text not null default '', hash text not null default '', block_height integer not null, created_at integer not null);
+	create table candidate_verification_sign_offs(candidate_address text not null, verifier text not null, verified text not null, block_height integer not null, hash text not null default '', unique(candidate_address, verifier) ON conflict replace);
+`
+
+func setupTestDb(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "stake")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbm.InitSqliter(filepath.Join(dir, "devchain.db")); err != nil {
+		t.Fatal(err)
+	}
+	_, err = getDb().Exec(testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		dbm.Sqliter.CloseDB()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestUpdateVotingPowerAdmission(t *testing.T) {
+	assert := assert.New(t)
+	defer setupTestDb(t)()
+
+	candidate := func(votingPower int64, active, admitted string) *Candidate {
+		return &Candidate{
+			PubKey:      types.PubKey{PubKey: ed25519.GenPrivKey().PubKey()},
+			VotingPower: votingPower,
+			Active:      active,
+			Admitted:    admitted,
+		}
+	}
+
+	var (
+		admitted    = candidate(0, "Y", "Y")
+		notAdmitted = candidate(0, "Y", "N")
+		validator   = candidate(1000, "Y", "N")
+		inactive    = candidate(1000, "N", "Y")
+	)
+
+	params := utils.DefaultParams()
+	params.ValidatorAdmissionRequired = true
+	utils.SetParams(params)
+	defer utils.SetParams(utils.DefaultParams())
+
+	Candidates{admitted, notAdmitted, validator, inactive}.updateVotingPower(nil)
+
+	assert.Equal("Validator", admitted.State, "admitted candidate is promoted")
+	assert.Equal(int64(1000), admitted.VotingPower)
+	assert.Equal("Candidate", notAdmitted.State, "candidate is kept until its admission")
+	assert.Equal(int64(0), notAdmitted.VotingPower)
+	assert.Equal("Validator", validator.State, "a validator before the param was set stays")
+	assert.Equal(int64(1000), validator.VotingPower)
+	assert.Equal("Candidate", inactive.State, "inactive candidate is demoted even if admitted")
+	assert.Equal(int64(0), inactive.VotingPower)
+
+	// without the param, declaring candidacy is enough
+	utils.SetParams(utils.DefaultParams())
+	Candidates{notAdmitted}.updateVotingPower(nil)
+	assert.Equal("Validator", notAdmitted.State)
+	assert.Equal(int64(1000), notAdmitted.VotingPower)
+}
@@ -14,6 +14,9 @@ const (
 	ChainKey = "chain_id"
 )
 
+// QueryHandler answers an ABCI query routed to a module by its path
+type QueryHandler func(data []byte) ([]byte, error)
+
 type Result interface {
 	GetData() []byte
 }
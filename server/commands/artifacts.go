@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/vangjvn/devchain/types"
+)
+
+const (
+	defaultArtifactsDir = "artifacts"
+
+	FlagOlderThan = "older-than"
+	FlagAll       = "all"
+)
+
+// ArtifactsCmd manages the cache of the library and program releases under the node home,
+// which can be pre-seeded for the nodes without access to the download urls
+var ArtifactsCmd = &cobra.Command{
+	Use:   "artifacts",
+	Short: "Manage the cached library and program releases",
+	Run:   func(cmd *cobra.Command, args []string) { cmd.Help() },
+}
+
+var listArtifactsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the cached artifacts",
+	RunE:  listArtifacts,
+}
+
+var importArtifactsCmd = &cobra.Command{
+	Use:   "import [file...]",
+	Short: "Import files into the cache, under their sha256 digests and md5 checksums",
+	RunE:  importArtifacts,
+}
+
+var pruneArtifactsCmd = &cobra.Command{
+	Use:   "prune [digest...]",
+	Short: "Remove the artifacts with the digests, the ones older than --older-than, or all of them with --all",
+	RunE:  pruneArtifacts,
+}
+
+func init() {
+	pruneArtifactsCmd.Flags().Duration(FlagOlderThan, 0, "remove the artifacts cached for longer than this, e.g. 720h")
+	pruneArtifactsCmd.Flags().Bool(FlagAll, false, "remove all the artifacts")
+
+	ArtifactsCmd.AddCommand(
+		listArtifactsCmd,
+		importArtifactsCmd,
+		pruneArtifactsCmd,
+	)
+}
+
+// artifactCache returns the cache of the library and program releases under the node home
+func artifactCache(rootDir string) *types.ArtifactCache {
+	return types.NewArtifactCache(filepath.Join(rootDir, defaultArtifactsDir), config.Download.Mirrors)
+}
+
+func listArtifacts(cmd *cobra.Command, args []string) error {
+	artifacts, err := artifactCache(config.BaseConfig.RootDir).List()
+	if err != nil {
+		return err
+	}
+	for _, a := range artifacts {
+		printArtifact(a)
+	}
+	return nil
+}
+
+func importArtifacts(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("please enter the files to import")
+	}
+
+	cache := artifactCache(config.BaseConfig.RootDir)
+	for _, file := range args {
+		artifacts, err := cache.Import(file)
+		if err != nil {
+			return err
+		}
+		for _, a := range artifacts {
+			printArtifact(a)
+		}
+	}
+	return nil
+}
+
+func pruneArtifacts(cmd *cobra.Command, args []string) error {
+	olderThan := viper.GetDuration(FlagOlderThan)
+	all := viper.GetBool(FlagAll)
+	if len(args) == 0 && olderThan == 0 && !all {
+		return fmt.Errorf("please enter the digests to remove, or use --older-than or --all")
+	}
+
+	digests := make(map[string]bool)
+	for _, d := range args {
+		digests[strings.ToLower(strings.TrimPrefix(d, "0x"))] = true
+	}
+
+	cache := artifactCache(config.BaseConfig.RootDir)
+	artifacts, err := cache.List()
+	if err != nil {
+		return err
+	}
+	for _, a := range artifacts {
+		if all || digests[a.Digest] || (olderThan > 0 && time.Since(a.ModTime) > olderThan) {
+			if err = cache.Remove(a); err != nil {
+				return err
+			}
+			printArtifact(a)
+		}
+	}
+	return nil
+}
+
+func printArtifact(a *types.CachedArtifact) {
+	fmt.Printf("%-6s %s %10d %s\n", a.Algorithm, a.Digest, a.Size, a.ModTime.Format(time.RFC3339))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/ethereum/go-ethereum/node"
+	"github.com/vangjvn/devchain/modules/governance"
 	"github.com/vangjvn/devchain/utils"
 	tmcfg "github.com/tendermint/tendermint/config"
 	cmn "github.com/tendermint/tendermint/libs/common"
@@ -23,9 +24,10 @@ const (
 )
 
 type TravisConfig struct {
-	BaseConfig BaseConfig      `mapstructure:",squash"`
-	TMConfig   tmcfg.Config    `mapstructure:",squash"`
-	EMConfig   EthermintConfig `mapstructure:"vm"`
+	BaseConfig BaseConfig                `mapstructure:",squash"`
+	TMConfig   tmcfg.Config              `mapstructure:",squash"`
+	EMConfig   EthermintConfig           `mapstructure:"vm"`
+	Download   governance.DownloadConfig `mapstructure:"download"`
 }
 
 func DefaultConfig() *TravisConfig {
@@ -33,6 +35,7 @@ func DefaultConfig() *TravisConfig {
 		BaseConfig: DefaultBaseConfig(),
 		TMConfig:   *tmcfg.DefaultConfig(),
 		EMConfig:   DefaultEthermintConfig(),
+		Download:   governance.DefaultDownloadConfig(),
 	}
 }
 
@@ -183,4 +186,10 @@ rpcvhosts = "{{ .EMConfig.RPCVirtualHostsFlag }}"
 ws = {{ .EMConfig.WSEnabledFlag }}
 ipcdisable = {{ .EMConfig.IPCDisabledFlag }}
 verbosity = "{{ .EMConfig.VerbosityFlag }}"
+
+[download]
+min_backoff = {{ .Download.MinBackoff }}
+max_backoff = {{ .Download.MaxBackoff }}
+max_attempts = {{ .Download.MaxAttempts }}
+mirrors = [{{ range $i, $m := .Download.Mirrors }}{{ if $i }}, {{ end }}"{{ $m }}"{{ end }}]
 `
@@ -171,30 +171,66 @@ func initDevChainDb() {
 		defer db.Close()
 
 		sqlStmt := `
-	create table candidates(id integer not null primary key autoincrement, address text not null, pub_key text not null, voting_power integer default 0, name text not null default '', website text not null default '', location text not null default '', email text not null default '', profile text not null default '', verified text not null default 'N', active text not null default 'Y', state text not null default '', hash text not null default '', block_height integer not null, created_at integer not null);
+	create table candidates(id integer not null primary key autoincrement, address text not null, pub_key text not null, voting_power integer default 0, name text not null default '', website text not null default '', location text not null default '', email text not null default '', profile text not null default '', verified text not null default 'N', active text not null default 'Y', admitted text not null default 'N', state text not null default '', hash text not null default '', block_height integer not null, created_at integer not null);
 	create unique index idx_candidates_pub_key on candidates(pub_key);
 	create unique index idx_candidates_address on candidates(address);
 	create index idx_candidates_hash on candidates(hash);
 	create table candidate_account_update_requests(id integer primary key autoincrement, candidate_id integer not null, from_address text not null, to_address text not null, created_block_height integer not null, accepted_block_height integer not null, state text not null, hash text not null default '');
 	create index idx_candidate_account_update_requests_to_address on candidate_account_update_requests(to_address);
 	create index idx_candidate_account_update_requests_hash on candidate_account_update_requests(hash);
+	create table candidate_verification_sign_offs(candidate_address text not null, verifier text not null, verified text not null, block_height integer not null, hash text not null default '', unique(candidate_address, verifier) ON conflict replace);
+	create index idx_candidate_verification_sign_offs_candidate_address on candidate_verification_sign_offs(candidate_address);
 
- 	create table governance_proposal(id text not null primary key, type text not null, proposer text not null, block_height integer not null, expire_timestamp integer not null, expire_block_height integer not null, hash text not null default '', result text not null default '', result_msg text not null default '', result_block_height integer not null default 0);
+ 	create table governance_proposal(id text not null primary key, type text not null, proposer text not null, block_height integer not null, expire_timestamp integer not null, expire_block_height integer not null, hash text not null default '', result text not null default '', result_msg text not null default '', result_block_height integer not null default 0, deposit text not null default '0', execution_delay integer not null default 0, activation_block_height integer not null default 0, execute_block_height integer not null default 0);
 	create index idx_governance_proposal_hash on governance_proposal(hash);
+	create index idx_governance_proposal_proposer on governance_proposal(proposer);
+	create index idx_governance_proposal_block_height on governance_proposal(block_height);
  	create table governance_transfer_fund_detail(proposal_id text not null, from_address text not null, to_address text not null, amount text not null, reason text not null);
 	create index idx_governance_transfer_fund_detail_proposal_id on governance_transfer_fund_detail(proposal_id);
- 	create table governance_change_param_detail(proposal_id text not null, param_name text not null, param_value text not null, reason text not null);
+ 	create table governance_change_param_detail(proposal_id text not null, param_name text not null, param_value text not null, reason text not null, params text not null default '');
 	create index idx_governance_change_param_detail_proposal_id on governance_change_param_detail(proposal_id);
-	create table governance_deploy_libeni_detail(proposal_id text not null, name text not null, version text not null, fileurl text not null, md5 text not null, reason text not null, status text not null);
+	create table governance_deploy_libeni_detail(proposal_id text not null, name text not null, version text not null, fileurl text not null, md5 text not null, sha256 text not null default '', signature text not null default '', reason text not null, status text not null);
 	create index idx_governance_deploy_libeni_detail_proposal_id on governance_deploy_libeni_detail(proposal_id);
 	create table governance_retire_program_detail(proposal_id text not null, retired_version text not null, preserved_validators text not null, reason text not null, status text not null);
 	create index idx_governance_retire_program_detail_proposal_id on governance_retire_program_detail(proposal_id);
-	create table governance_upgrade_program_detail(proposal_id text not null, retired_version text not null, name text not null, version text not null, fileurl text not null, md5 text not null, reason text not null);
+	create table governance_upgrade_program_detail(proposal_id text not null, retired_version text not null, name text not null, version text not null, fileurl text not null, md5 text not null, sha256 text not null default '', signature text not null default '', reason text not null);
 	create index idx_governance_upgrade_program_detail_proposal_id on governance_retire_program_detail(proposal_id);
+	create table governance_text_detail(proposal_id text not null, title text not null, description text not null, document_url text not null, document_hash text not null);
+	create index idx_governance_text_detail_proposal_id on governance_text_detail(proposal_id);
+	create table governance_validator_detail(proposal_id text not null, candidate_address text not null, reason text not null);
+	create index idx_governance_validator_detail_proposal_id on governance_validator_detail(proposal_id);
+	create table governance_grant_detail(proposal_id text not null, from_address text not null, to_address text not null, amount text not null, tranches integer not null, tranche_interval integer not null, reason text not null);
+	create index idx_governance_grant_detail_proposal_id on governance_grant_detail(proposal_id);
+	create table governance_cancel_grant_detail(proposal_id text not null, grant_id text not null, reason text not null);
+	create index idx_governance_cancel_grant_detail_proposal_id on governance_cancel_grant_detail(proposal_id);
+	create table governance_grant(proposal_id text not null primary key, from_address text not null, to_address text not null, amount text not null, paid text not null default '0', tranches integer not null, tranches_paid integer not null default 0, tranche_interval integer not null, next_block_height integer not null, status text not null, block_height integer not null, hash text not null default '');
+	create index idx_governance_grant_status_next_block_height on governance_grant(status, next_block_height);
+	create table governance_contract_call_detail(proposal_id text not null, to_address text not null, value text not null, data text not null, gas integer not null, reason text not null);
+	create index idx_governance_contract_call_detail_proposal_id on governance_contract_call_detail(proposal_id);
+	create table governance_pause_detail(proposal_id text not null, contracts text not null, duration integer not null, reason text not null);
+	create index idx_governance_pause_detail_proposal_id on governance_pause_detail(proposal_id);
+	create table governance_unpause_detail(proposal_id text not null, reason text not null);
+	create index idx_governance_unpause_detail_proposal_id on governance_unpause_detail(proposal_id);
+	create table governance_pause(proposal_id text not null primary key, contracts text not null, start_block_height integer not null, end_block_height integer not null, status text not null, lifted_by text not null default '', block_height integer not null, hash text not null default '');
+	create index idx_governance_pause_status on governance_pause(status);
+	create table governance_consensus_params_detail(proposal_id text not null, block_max_bytes integer, block_max_txs integer, block_max_gas integer, tx_max_bytes integer, tx_max_gas integer, block_part_size_bytes integer, reason text not null);
+	create index idx_governance_consensus_params_detail_proposal_id on governance_consensus_params_detail(proposal_id);
+	create table governance_consensus_params(proposal_id text not null primary key, block_height integer not null, status text not null, params text not null default '', hash text not null default '');
+	create index idx_governance_consensus_params_status_block_height on governance_consensus_params(status, block_height);
+	create table governance_contract_call_receipt(proposal_id text not null primary key, status integer not null, gas_used integer not null, return_data text not null, logs text not null, revert_reason text not null, error text not null, block_height integer not null);
  	create table governance_vote(proposal_id text not null, voter text not null, block_height integer not null, answer text not null,  hash text not null default '', unique(proposal_id, voter) ON conflict replace);
 	create index idx_governance_vote_voter on governance_vote(voter);
 	create index idx_governance_vote_proposal_id on governance_vote(proposal_id);
 	create index idx_governance_vote_hash on governance_vote(hash);
+	create table governance_tally(proposal_id text not null primary key, yes_power integer not null, no_power integer not null, abstain_power integer not null, veto_power integer not null, total_power integer not null, block_height integer not null);
+	create table governance_validator_snapshot(proposal_id text not null, owner_address text not null, voting_power integer not null, delegatee text not null default '', hash text not null default '', unique(proposal_id, owner_address));
+	create index idx_governance_validator_snapshot_proposal_id on governance_validator_snapshot(proposal_id);
+	create table governance_vote_delegation(delegator text not null primary key, delegatee text not null, block_height integer not null, hash text not null default '');
+	create index idx_governance_vote_delegation_delegatee on governance_vote_delegation(delegatee);
+	create table governance_event(id integer primary key autoincrement, proposal_id text not null, type text not null, actor text not null default '', detail text not null default '', block_height integer not null, prev_hash text not null default '', hash text not null);
+	create index idx_governance_event_proposal_id on governance_event(proposal_id);
+	create trigger governance_event_no_update before update on governance_event begin select raise(abort, 'governance_event is append-only'); end;
+	create trigger governance_event_no_delete before delete on governance_event begin select raise(abort, 'governance_event is append-only'); end;
 	`
 		_, err = db.Exec(sqlStmt)
 		if err != nil {
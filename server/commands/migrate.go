@@ -0,0 +1,160 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/vangjvn/devchain/sdk/dbm"
+)
+
+// migration brings the devchain database of a node initialized by an older version up to the schema of initDevChainDb.
+// The migrations are run every time the node starts, so every one of them has to be idempotent:
+// the tables, indexes and triggers are created if not exists, and a column is added only if it is missing.
+type migration struct {
+	// table and column of the column to add, empty for a statement
+	table  string
+	column string
+	// definition of the column to add, or the statement to execute
+	stmt string
+}
+
+func addColumn(table, column, definition string) migration {
+	return migration{table, column, definition}
+}
+
+func execStmt(stmt string) migration {
+	return migration{stmt: stmt}
+}
+
+var migrations = []migration{
+	// tallies of the proposals
+	execStmt("create table if not exists governance_tally(proposal_id text not null primary key, yes_power integer not null, no_power integer not null, abstain_power integer not null, veto_power integer not null, total_power integer not null, block_height integer not null)"),
+	// deposits of the proposals
+	addColumn("governance_proposal", "deposit", "text not null default '0'"),
+	// text proposals
+	execStmt("create table if not exists governance_text_detail(proposal_id text not null, title text not null, description text not null, document_url text not null, document_hash text not null)"),
+	execStmt("create index if not exists idx_governance_text_detail_proposal_id on governance_text_detail(proposal_id)"),
+	// multi-param change proposals
+	addColumn("governance_change_param_detail", "params", "text not null default ''"),
+	// timelocked execution of the approved proposals
+	addColumn("governance_proposal", "execution_delay", "integer not null default 0"),
+	addColumn("governance_proposal", "activation_block_height", "integer not null default 0"),
+	addColumn("governance_proposal", "execute_block_height", "integer not null default 0"),
+	// filtered proposal queries
+	execStmt("create index if not exists idx_governance_proposal_proposer on governance_proposal(proposer)"),
+	execStmt("create index if not exists idx_governance_proposal_block_height on governance_proposal(block_height)"),
+	// append-only event log
+	execStmt("create table if not exists governance_event(id integer primary key autoincrement, proposal_id text not null, type text not null, actor text not null default '', detail text not null default '', block_height integer not null, prev_hash text not null default '', hash text not null)"),
+	execStmt("create index if not exists idx_governance_event_proposal_id on governance_event(proposal_id)"),
+	execStmt("create trigger if not exists governance_event_no_update before update on governance_event begin select raise(abort, 'governance_event is append-only'); end"),
+	execStmt("create trigger if not exists governance_event_no_delete before delete on governance_event begin select raise(abort, 'governance_event is append-only'); end"),
+	// vote delegations
+	execStmt("create table if not exists governance_vote_delegation(delegator text not null primary key, delegatee text not null, block_height integer not null)"),
+	execStmt("create index if not exists idx_governance_vote_delegation_delegatee on governance_vote_delegation(delegatee)"),
+	// validator snapshots of the proposals
+	execStmt("create table if not exists governance_validator_snapshot(proposal_id text not null, owner_address text not null, voting_power integer not null, unique(proposal_id, owner_address))"),
+	execStmt("create index if not exists idx_governance_validator_snapshot_proposal_id on governance_validator_snapshot(proposal_id)"),
+	// admission and eviction of the validators by governance
+	addColumn("candidates", "admitted", "text not null default 'N'"),
+	execStmt("create table if not exists governance_validator_detail(proposal_id text not null, candidate_address text not null, reason text not null)"),
+	execStmt("create index if not exists idx_governance_validator_detail_proposal_id on governance_validator_detail(proposal_id)"),
+	// sign offs of the foundation committee
+	execStmt("create table if not exists candidate_verification_sign_offs(candidate_address text not null, verifier text not null, verified text not null, block_height integer not null, unique(candidate_address, verifier) ON conflict replace)"),
+	execStmt("create index if not exists idx_candidate_verification_sign_offs_candidate_address on candidate_verification_sign_offs(candidate_address)"),
+	// streaming grants
+	execStmt("create table if not exists governance_grant_detail(proposal_id text not null, from_address text not null, to_address text not null, amount text not null, tranches integer not null, tranche_interval integer not null, reason text not null)"),
+	execStmt("create index if not exists idx_governance_grant_detail_proposal_id on governance_grant_detail(proposal_id)"),
+	execStmt("create table if not exists governance_cancel_grant_detail(proposal_id text not null, grant_id text not null, reason text not null)"),
+	execStmt("create index if not exists idx_governance_cancel_grant_detail_proposal_id on governance_cancel_grant_detail(proposal_id)"),
+	execStmt("create table if not exists governance_grant(proposal_id text not null primary key, from_address text not null, to_address text not null, amount text not null, paid text not null default '0', tranches integer not null, tranches_paid integer not null default 0, tranche_interval integer not null, next_block_height integer not null, status text not null, block_height integer not null)"),
+	execStmt("create index if not exists idx_governance_grant_status_next_block_height on governance_grant(status, next_block_height)"),
+	// contract call proposals
+	execStmt("create table if not exists governance_contract_call_detail(proposal_id text not null, to_address text not null, value text not null, data text not null, gas integer not null, reason text not null)"),
+	execStmt("create index if not exists idx_governance_contract_call_detail_proposal_id on governance_contract_call_detail(proposal_id)"),
+	execStmt("create table if not exists governance_contract_call_receipt(proposal_id text not null primary key, status integer not null, gas_used integer not null, return_data text not null, logs text not null, revert_reason text not null, error text not null, block_height integer not null)"),
+	// emergency pauses
+	execStmt("create table if not exists governance_pause_detail(proposal_id text not null, contracts text not null, duration integer not null, reason text not null)"),
+	execStmt("create index if not exists idx_governance_pause_detail_proposal_id on governance_pause_detail(proposal_id)"),
+	execStmt("create table if not exists governance_unpause_detail(proposal_id text not null, reason text not null)"),
+	execStmt("create index if not exists idx_governance_unpause_detail_proposal_id on governance_unpause_detail(proposal_id)"),
+	execStmt("create table if not exists governance_pause(proposal_id text not null primary key, contracts text not null, start_block_height integer not null, end_block_height integer not null, status text not null, lifted_by text not null default '', block_height integer not null)"),
+	execStmt("create index if not exists idx_governance_pause_status on governance_pause(status)"),
+	// consensus params proposals
+	execStmt("create table if not exists governance_consensus_params_detail(proposal_id text not null, block_max_bytes integer, block_max_txs integer, block_max_gas integer, tx_max_bytes integer, tx_max_gas integer, block_part_size_bytes integer, reason text not null)"),
+	execStmt("create index if not exists idx_governance_consensus_params_detail_proposal_id on governance_consensus_params_detail(proposal_id)"),
+	execStmt("create table if not exists governance_consensus_params(proposal_id text not null primary key, block_height integer not null, status text not null, params text not null default '')"),
+	execStmt("create index if not exists idx_governance_consensus_params_status_block_height on governance_consensus_params(status, block_height)"),
+	// signed SHA-256 digests of the releases, empty for the legacy releases
+	addColumn("governance_deploy_libeni_detail", "sha256", "text not null default ''"),
+	addColumn("governance_deploy_libeni_detail", "signature", "text not null default ''"),
+	addColumn("governance_upgrade_program_detail", "sha256", "text not null default ''"),
+	addColumn("governance_upgrade_program_detail", "signature", "text not null default ''"),
+	// hashes of the consensus relevant governance and stake tables, the snapshot of the vote delegations
+	addColumn("governance_vote_delegation", "hash", "text not null default ''"),
+	addColumn("governance_validator_snapshot", "delegatee", "text not null default ''"),
+	addColumn("governance_validator_snapshot", "hash", "text not null default ''"),
+	addColumn("candidate_verification_sign_offs", "hash", "text not null default ''"),
+	addColumn("governance_grant", "hash", "text not null default ''"),
+	addColumn("governance_pause", "hash", "text not null default ''"),
+	addColumn("governance_consensus_params", "hash", "text not null default ''"),
+}
+
+// migrateDevChainDb applies the migrations to the devchain database in a single transaction
+func migrateDevChainDb() error {
+	db, err := dbm.Sqliter.GetDB()
+	if err != nil {
+		return err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		stmt := m.stmt
+		if m.column != "" {
+			exists, err := hasColumn(tx, m.table, m.column)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+			if exists {
+				continue
+			}
+			stmt = fmt.Sprintf("alter table %s add column %s %s", m.table, m.column, m.stmt)
+		}
+		if _, err = tx.Exec(stmt); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migrate devchain database: %s: %v", stmt, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	log.Info("Devchain database migrated")
+	return nil
+}
+
+func hasColumn(tx *sql.Tx, table, column string) (bool, error) {
+	rows, err := tx.Query("pragma table_info(" + table + ")")
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, ctype string
+		var dflt sql.NullString
+		if err = rows.Scan(&cid, &name, &ctype, &notNull, &dflt, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
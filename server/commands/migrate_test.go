@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/vangjvn/devchain/sdk/dbm"
+	"github.com/vangjvn/devchain/utils"
+)
+
+// legacySchema is the devchain database created by the versions before the migrations
+const legacySchema = `
+	create table candidates(id integer not null primary key autoincrement, address text not null, pub_key text not null, voting_power integer default 0, name text not null default '', website text not null default '', location text not null default '', email text not null default '', profile text not null default '', verified text not null default 'N', active text not null default 'Y', state text not null default '', hash text not null default '', block_height integer not null, created_at integer not null);
+	create table candidate_account_update_requests(id integer primary key autoincrement, candidate_id integer not null, from_address text not null, to_address text not null, created_block_height integer not null, accepted_block_height integer not null, state text not null, hash text not null default '');
+	create table governance_proposal(id text not null primary key, type text not null, proposer text not null, block_height integer not null, expire_timestamp integer not null, expire_block_height integer not null, hash text not null default '', result text not null default '', result_msg text not null default '', result_block_height integer not null default 0);
+	create table governance_transfer_fund_detail(proposal_id text not null, from_address text not null, to_address text not null, amount text not null, reason text not null);
+	create table governance_change_param_detail(proposal_id text not null, param_name text not null, param_value text not null, reason text not null);
+	create table governance_deploy_libeni_detail(proposal_id text not null, name text not null, version text not null, fileurl text not null, md5 text not null, reason text not null, status text not null);
+	create table governance_retire_program_detail(proposal_id text not null, retired_version text not null, preserved_validators text not null, reason text not null, status text not null);
+	create table governance_upgrade_program_detail(proposal_id text not null, retired_version text not null, name text not null, version text not null, fileurl text not null, md5 text not null, reason text not null);
+	create table governance_vote(proposal_id text not null, voter text not null, block_height integer not null, answer text not null,  hash text not null default '', unique(proposal_id, voter) ON conflict replace);
+	insert into governance_proposal(id, type, proposer, block_height, expire_timestamp, expire_block_height) values('legacy', 'transfer_fund', '0x7eff122b94897ea5b0e2a9abf47b86337fafebdc', 1, 0, 100);
+`
+
+// tableColumns returns the sorted column names of every table of the database
+func tableColumns(t *testing.T, db *sql.DB) map[string][]string {
+	rows, err := db.Query("select name from sqlite_master where type = 'table' and name not like 'sqlite_%'")
+	require.NoError(t, err)
+	var tables []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		tables = append(tables, name)
+	}
+	rows.Close()
+
+	columns := make(map[string][]string)
+	for _, table := range tables {
+		rows, err := db.Query("pragma table_info(" + table + ")")
+		require.NoError(t, err)
+		for rows.Next() {
+			var cid, notNull, pk int
+			var name, ctype string
+			var dflt sql.NullString
+			require.NoError(t, rows.Scan(&cid, &name, &ctype, &notNull, &dflt, &pk))
+			columns[table] = append(columns[table], name)
+		}
+		rows.Close()
+		sort.Strings(columns[table])
+	}
+	return columns
+}
+
+func TestMigrateDevChainDb(t *testing.T) {
+	dir, err := ioutil.TempDir("", "devchain")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the schema of a node initialized by this version
+	viper.Set(cli.HomeFlag, dir)
+	defer viper.Set(cli.HomeFlag, "")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "data"), 0700))
+	initDevChainDb()
+	fresh, err := sql.Open("sqlite3", filepath.Join(dir, "data", utils.DB_FILE_NAME))
+	require.NoError(t, err)
+	defer fresh.Close()
+	expected := tableColumns(t, fresh)
+
+	legacyPath := filepath.Join(dir, "legacy.db")
+	require.NoError(t, dbm.InitSqliter(legacyPath))
+	defer dbm.Sqliter.CloseDB()
+	db, err := dbm.Sqliter.GetDB()
+	require.NoError(t, err)
+	_, err = db.Exec(legacySchema)
+	require.NoError(t, err)
+
+	// the migrations run at every start
+	require.NoError(t, migrateDevChainDb())
+	require.NoError(t, migrateDevChainDb())
+
+	assert.Equal(t, expected, tableColumns(t, db))
+
+	var deposit string
+	var executionDelay int64
+	err = db.QueryRow("select deposit, execution_delay from governance_proposal where id = 'legacy'").Scan(&deposit, &executionDelay)
+	require.NoError(t, err)
+	assert.Equal(t, "0", deposit)
+	assert.Equal(t, int64(0), executionDelay)
+}
@@ -19,9 +19,10 @@ import (
 	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/libs/cli"
 	cmn "github.com/tendermint/tendermint/libs/common"
+	ttypes "github.com/tendermint/tendermint/types"
 
 	"github.com/vangjvn/devchain/app"
-	"github.com/vangjvn/devchain/modules/stake"
+	"github.com/vangjvn/devchain/modules/governance"
 	"github.com/vangjvn/devchain/sdk/dbm"
 	"github.com/vangjvn/devchain/server"
 	"github.com/vangjvn/devchain/types"
@@ -60,6 +61,10 @@ func startCmd() func(cmd *cobra.Command, args []string) error {
 		if err := dbm.InitSqliter(path.Join(rootDir, "data", utils.DB_FILE_NAME)); err != nil {
 			return err
 		}
+		// the database may have been initialized by an older version
+		if err := migrateDevChainDb(); err != nil {
+			return err
+		}
 
 		cmdName := cmd.Root().Name()
 		appName := fmt.Sprintf("%s v%v", cmdName, version.Version)
@@ -122,6 +127,10 @@ func start(rootDir string, storeApp *app.StoreApp) error {
 }
 
 func createBaseApp(rootDir string, storeApp *app.StoreApp, ethApp *app.EthermintApplication, ethereum *eth.Ethereum) (*app.BaseApp, error) {
+	utils.SetGovernanceUpgradeHeight(config.EMConfig.ChainId)
+	// the pending libeni downloads are resumed when NewBaseApp loads the modules
+	governance.SetDownloadConfig(config.Download)
+	governance.SetArtifactCache(artifactCache(rootDir))
 	app, err := app.NewBaseApp(storeApp, ethApp, ethereum)
 	if err != nil {
 		return nil, err
@@ -139,14 +148,22 @@ func createBaseApp(rootDir string, storeApp *app.StoreApp, ethApp *app.Ethermint
 
 			app.SetChainId(genDoc.ChainID)
 			utils.SetParams(genDoc.Params)
-			for _, val := range genDoc.Validators {
-				stake.SetGenesisValidator(val, app.Append())
+			if err := app.InitGenesis(genDoc); err != nil {
+				return nil, errors.Errorf("Error in InitGenesis: %v\n", err)
 			}
 		} else {
 			fmt.Printf("No genesis file at %s, skipping...\n", genesisFile)
 		}
 	}
 
+	// the consensus params changed by governance are applied over the ones of the genesis
+	var genesisParams *ttypes.ConsensusParams
+	genesisFile := path.Join(rootDir, DefaultConfig().TMConfig.GenesisFile())
+	if genDoc, err := loadGenesis(genesisFile); err == nil {
+		genesisParams = genDoc.ConsensusParams
+	}
+	governance.LoadConsensusParams(genesisParams)
+
 	chainID := app.GetChainID()
 	logger.Info("Starting Travis", "chain_id", chainID)
 
@@ -175,9 +192,12 @@ func startSubProcess(rootDir string) error {
 	args = append(args, arg)
 
 	cmd := types.NewTravisCmd(rootDir, path.Base(os.Args[0]), args...)
+	cmd.Artifacts = artifactCache(rootDir)
 	m := types.NewMonitor(cmd)
 	startRPC(m)
-	cmd.Start()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
 
 	go startRoutine(cmd)
 
@@ -211,10 +231,12 @@ func startRoutine(c *types.TravisCmd) {
 			}
 		case cmdInfo := <-c.UpgradeChan:
 			fmt.Printf("Start to upgrade %s\n", cmdInfo.Name)
-			if c.NextName != cmdInfo.ReleaseName() {
-				log.Fatalf("Upgrade want version (%s) but get version: (%s)\n", cmdInfo.Name, c.NextName)
-			}
 			if err := c.Upgrade(cmdInfo); err != nil {
+				// the previous version keeps running if the new one has been rolled back
+				if _, ok := err.(*types.RolledBackError); ok {
+					log.Printf("Upgrade failed: %s\n", err)
+					continue
+				}
 				log.Fatalf("Upgrade failed: %s\n", err)
 			}
 		case <-c.KillChan:
@@ -66,19 +66,19 @@ describe("Governance Test", function() {
         balance_old = Utils.getBalance()
       })
 
-      it("Validators V propose to modify foundation address. ", function() {
+      it("Validators V propose to modify foundation committee. ", function() {
         tx_result = web3.cmt.governance.proposeChangeParam({
           from: V,
-          name: "foundation_address",
+          name: "foundation_committee",
           value: "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc"
         })
         Utils.expectTxSuccess(tx_result)
         proposalId = tx_result.deliver_tx.data
       })
 
-      it("foundation_address won't change before vote. ", function() {
+      it("foundation_committee won't change before vote. ", function() {
         new_params = web3.cmt.governance.getParams()
-        expect(new_params.data.foundation_address).to.equal(old_params.data.foundation_address)
+        expect(new_params.data.foundation_committee).to.equal(old_params.data.foundation_committee)
       })
 
       it("vote the proposal. ", function(done) {
@@ -106,11 +106,11 @@ describe("Governance Test", function() {
         })
       })
 
-      it("Verify the foundation address was changed. ", function() {
+      it("Verify the foundation committee was changed. ", function() {
         new_params = web3.cmt.governance.getParams()
         logger.debug(new_params)
-        expect(new_params.data.foundation_address).to.equal("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
-        Globals.Params.foundation_address = old_params.data.foundation_address
+        expect(new_params.data.foundation_committee).to.equal("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+        Globals.Params.foundation_committee = old_params.data.foundation_committee
       })
     })
 
@@ -0,0 +1,172 @@
+package types
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tendermint/ed25519"
+)
+
+// ArtifactChecksum identifies a release artifact, i.e. a library or a program binary.
+// A release is identified by its SHA-256 digest, signed with ed25519 by one of the publisher keys,
+// the signature is made over the 32 bytes of the digest. Legacy releases have only the md5 checksum.
+type ArtifactChecksum struct {
+	Sha256    string
+	Signature string
+	MD5       string
+}
+
+// Legacy tells whether the release is identified by its md5 checksum only
+func (c *ArtifactChecksum) Legacy() bool {
+	return c.Sha256 == ""
+}
+
+// VerifySignature checks the digest is signed by one of the hex encoded ed25519 publisher keys.
+// The signature is not checked if no publisher key is configured, the release is then trusted by its digest only.
+func (c *ArtifactChecksum) VerifySignature(publisherKeys []string) error {
+	digest, err := hex.DecodeString(strings.TrimPrefix(c.Sha256, "0x"))
+	if err != nil || len(digest) != sha256.Size {
+		return errors.New("invalid sha256 digest")
+	}
+	if len(publisherKeys) == 0 {
+		return nil
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(c.Signature, "0x"))
+	if err != nil || len(b) != ed25519.SignatureSize {
+		return errors.New("invalid ed25519 signature")
+	}
+	var sig [ed25519.SignatureSize]byte
+	copy(sig[:], b)
+
+	for _, k := range publisherKeys {
+		b, err := hex.DecodeString(strings.TrimPrefix(k, "0x"))
+		if err != nil || len(b) != ed25519.PublicKeySize {
+			continue
+		}
+		var key [ed25519.PublicKeySize]byte
+		copy(key[:], b)
+		if ed25519.Verify(&key, digest, &sig) {
+			return nil
+		}
+	}
+	return errors.New("the sha256 digest is not signed by any publisher key")
+}
+
+// VerifyFile checks the file against the checksum, and the signature of a non legacy release
+func (c *ArtifactChecksum) VerifyFile(path string, publisherKeys []string) error {
+	if !c.Legacy() {
+		if err := c.VerifySignature(publisherKeys); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	expected, h := c.MD5, md5.New()
+	if !c.Legacy() {
+		expected, h = c.Sha256, sha256.New()
+	}
+	return checkDigest(f, h, expected)
+}
+
+func checkDigest(r io.Reader, h hash.Hash, expected string) error {
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(actual, strings.TrimPrefix(expected, "0x")) {
+		return fmt.Errorf("checksum mismatch, expected %s but got %s", expected, actual)
+	}
+	return nil
+}
+
+// FileMd5 returns the hex encoded md5 checksum of the file
+func FileMd5(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// DownloadArtifact downloads the artifact from the first url serving it, and verifies it before moving it to dest.
+// Nothing is left at dest if no url serves a verified artifact. The download is interrupted once ctx is done.
+func DownloadArtifact(ctx context.Context, urls []string, dest string, checksum *ArtifactChecksum, publisherKeys []string) error {
+	if len(urls) == 0 {
+		return errors.New("no download url")
+	}
+
+	var err error
+	for _, url := range urls {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var part string
+		if part, err = downloadFile(ctx, url, filepath.Dir(dest), filepath.Base(dest)+".part"); err == nil {
+			if err = checksum.VerifyFile(part, publisherKeys); err == nil {
+				return os.Rename(part, dest)
+			}
+		}
+		if part != "" {
+			os.Remove(part)
+		}
+	}
+	return err
+}
+
+// downloadFile downloads the url to a new file in the dir, file:// urls are copied from the local file system
+func downloadFile(ctx context.Context, url, dir, prefix string) (string, error) {
+	var r io.ReadCloser
+	if strings.HasPrefix(url, "file://") {
+		f, err := os.Open(strings.TrimPrefix(url, "file://"))
+		if err != nil {
+			return "", err
+		}
+		r = f
+	} else {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return "", err
+		}
+		resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return "", fmt.Errorf("download %s: %s", url, resp.Status)
+		}
+		r = resp.Body
+	}
+	defer r.Close()
+
+	f, err := ioutil.TempFile(dir, prefix)
+	if err != nil {
+		return "", err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		f.Close()
+		return f.Name(), err
+	}
+	return f.Name(), f.Close()
+}
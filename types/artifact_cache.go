@@ -0,0 +1,174 @@
+package types
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	ARTIFACT_SHA256 = "sha256"
+	ARTIFACT_MD5    = "md5"
+)
+
+// ArtifactCache is a content-addressed cache of the release artifacts, an artifact is stored
+// under <dir>/sha256/<digest>, or under <dir>/md5/<checksum> for the legacy releases.
+// The mirrors are searched with the same layout before the urls of the release,
+// e.g. a file:// url of a directory pre-seeded by the operator, or a local http server serving it.
+type ArtifactCache struct {
+	Dir     string
+	Mirrors []string
+}
+
+// CachedArtifact is an artifact stored in the cache
+type CachedArtifact struct {
+	Algorithm string    `json:"algorithm"`
+	Digest    string    `json:"digest"`
+	Size      int64     `json:"size"`
+	ModTime   time.Time `json:"mod_time"`
+}
+
+func NewArtifactCache(dir string, mirrors []string) *ArtifactCache {
+	return &ArtifactCache{dir, mirrors}
+}
+
+// key returns the algorithm and the digest the artifact is cached under
+func (c *ArtifactChecksum) key() (algorithm, digest string) {
+	if c.Legacy() {
+		return ARTIFACT_MD5, strings.ToLower(strings.TrimPrefix(c.MD5, "0x"))
+	}
+	return ARTIFACT_SHA256, strings.ToLower(strings.TrimPrefix(c.Sha256, "0x"))
+}
+
+func (ac *ArtifactCache) path(algorithm, digest string) string {
+	return filepath.Join(ac.Dir, algorithm, digest)
+}
+
+// Fetch returns the path of the verified artifact in the cache, it is downloaded from the mirrors,
+// or else from the urls of the release, if it is not cached yet. A corrupted artifact is downloaded again.
+// The download is interrupted once ctx is done.
+func (ac *ArtifactCache) Fetch(ctx context.Context, urls []string, checksum *ArtifactChecksum, publisherKeys []string) (string, error) {
+	algorithm, digest := checksum.key()
+	// the digest is a file name, it is checked before any file is touched
+	if b, err := hex.DecodeString(digest); err != nil || len(b) == 0 {
+		return "", errors.New("invalid checksum")
+	}
+	if !checksum.Legacy() {
+		if err := checksum.VerifySignature(publisherKeys); err != nil {
+			return "", err
+		}
+	}
+
+	path := ac.path(algorithm, digest)
+	if _, err := os.Stat(path); err == nil {
+		if err = checksum.VerifyFile(path, publisherKeys); err == nil {
+			return path, nil
+		}
+		os.Remove(path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+	var sources []string
+	for _, m := range ac.Mirrors {
+		sources = append(sources, strings.TrimSuffix(m, "/")+"/"+algorithm+"/"+digest)
+	}
+	if err := DownloadArtifact(ctx, append(sources, urls...), path, checksum, publisherKeys); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Import copies a file into the cache, it is stored under both its sha256 digest and its md5 checksum,
+// so that it is found by the legacy releases as well. The artifacts are returned.
+func (ac *ArtifactCache) Import(src string) ([]*CachedArtifact, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	if err = os.MkdirAll(ac.Dir, 0700); err != nil {
+		return nil, err
+	}
+	tmp, err := ioutil.TempFile(ac.Dir, "import")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	sha256Hash, md5Hash := sha256.New(), md5.New()
+	if _, err = io.Copy(io.MultiWriter(tmp, sha256Hash, md5Hash), in); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err = tmp.Close(); err != nil {
+		return nil, err
+	}
+
+	var artifacts []*CachedArtifact
+	for _, a := range []struct{ algorithm, digest string }{
+		{ARTIFACT_SHA256, hex.EncodeToString(sha256Hash.Sum(nil))},
+		{ARTIFACT_MD5, hex.EncodeToString(md5Hash.Sum(nil))},
+	} {
+		path := ac.path(a.algorithm, a.digest)
+		if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, err
+		}
+		os.Remove(path)
+		if err = os.Link(tmp.Name(), path); err != nil {
+			return nil, err
+		}
+		var artifact *CachedArtifact
+		if artifact, err = ac.stat(a.algorithm, a.digest); err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts, nil
+}
+
+func (ac *ArtifactCache) stat(algorithm, digest string) (*CachedArtifact, error) {
+	fi, err := os.Stat(ac.path(algorithm, digest))
+	if err != nil {
+		return nil, err
+	}
+	return &CachedArtifact{algorithm, digest, fi.Size(), fi.ModTime()}, nil
+}
+
+// List returns the cached artifacts, the downloads in progress are skipped
+func (ac *ArtifactCache) List() ([]*CachedArtifact, error) {
+	var artifacts []*CachedArtifact
+	for _, algorithm := range []string{ARTIFACT_SHA256, ARTIFACT_MD5} {
+		files, err := ioutil.ReadDir(filepath.Join(ac.Dir, algorithm))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, fi := range files {
+			if fi.IsDir() || strings.Contains(fi.Name(), ".") {
+				continue
+			}
+			artifacts = append(artifacts, &CachedArtifact{algorithm, fi.Name(), fi.Size(), fi.ModTime()})
+		}
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].ModTime.After(artifacts[j].ModTime) })
+	return artifacts, nil
+}
+
+// Remove removes the artifact from the cache
+func (ac *ArtifactCache) Remove(a *CachedArtifact) error {
+	return os.Remove(ac.path(a.Algorithm, a.Digest))
+}
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtifactCacheFetch(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "artifacts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	data := []byte("libeni")
+	key, sign := newPublisher(t)
+	checksum := sign(data)
+	md5Sum := md5.Sum(data)
+	legacy := &ArtifactChecksum{MD5: hex.EncodeToString(md5Sum[:])}
+
+	release := filepath.Join(dir, "release")
+	other := filepath.Join(dir, "other")
+	// laid out as the cache
+	mirror := filepath.Join(dir, "mirror")
+	for path, content := range map[string][]byte{
+		release: data,
+		other:   []byte("other"),
+		filepath.Join(mirror, ARTIFACT_SHA256, checksum.Sha256): data,
+	} {
+		if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(path, content, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		name     string
+		checksum *ArtifactChecksum
+		urls     []string
+		mirrors  []string
+		// content cached before the fetch, if any
+		cached    []byte
+		cancelled bool
+		valid     bool
+	}{
+		{"downloaded", checksum, []string{"file://" + release}, nil, nil, false, true},
+		{"downloaded from the next url", checksum, []string{"file://" + other, "file://" + release}, nil, nil, false, true},
+		{"found in a mirror", checksum, nil, []string{"file://" + mirror}, nil, false, true},
+		{"already cached", checksum, nil, nil, data, false, true},
+		{"corrupted in the cache", checksum, []string{"file://" + release}, nil, []byte("corrupted"), false, true},
+		{"legacy", legacy, []string{"file://" + release}, nil, nil, false, true},
+		{"other content", checksum, []string{"file://" + other}, nil, nil, false, false},
+		{"no url", checksum, nil, nil, nil, false, false},
+		{"not signed", &ArtifactChecksum{Sha256: checksum.Sha256}, []string{"file://" + release}, nil, nil, false, false},
+		{"invalid digest", &ArtifactChecksum{MD5: "../release"}, []string{"file://" + release}, nil, nil, false, false},
+		{"cancelled", checksum, []string{"file://" + release}, nil, nil, true, false},
+	}
+
+	for i, c := range cases {
+		cache := NewArtifactCache(filepath.Join(dir, "cache", strconv.Itoa(i)), c.mirrors)
+		algorithm, digest := c.checksum.key()
+		if c.cached != nil {
+			path := cache.path(algorithm, digest)
+			if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+				t.Fatal(err)
+			}
+			if err = ioutil.WriteFile(path, c.cached, 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		if c.cancelled {
+			cancel()
+		}
+		path, err := cache.Fetch(ctx, c.urls, c.checksum, []string{key})
+		cancel()
+
+		if !c.valid {
+			assert.Error(err, c.name)
+			continue
+		}
+		assert.NoError(err, c.name)
+		assert.Equal(cache.path(algorithm, digest), path, c.name)
+		content, _ := ioutil.ReadFile(path)
+		assert.Equal(data, content, c.name)
+	}
+}
+
+func TestArtifactCacheImport(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "artifacts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, sign := newPublisher(t)
+	cache := NewArtifactCache(filepath.Join(dir, "cache"), nil)
+
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"first artifact", []byte("libeni")},
+		{"imported again", []byte("libeni")},
+		{"second artifact", []byte("travis")},
+	}
+
+	for _, c := range cases {
+		src := filepath.Join(dir, "src")
+		if err = ioutil.WriteFile(src, c.data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		artifacts, err := cache.Import(src)
+		if !assert.NoError(err, c.name) {
+			continue
+		}
+
+		checksum := sign(c.data)
+		md5Sum := md5.Sum(c.data)
+		legacy := &ArtifactChecksum{MD5: hex.EncodeToString(md5Sum[:])}
+		if assert.Equal(2, len(artifacts), c.name) {
+			assert.Equal(CachedArtifact{ARTIFACT_SHA256, checksum.Sha256, int64(len(c.data)), artifacts[0].ModTime}, *artifacts[0], c.name)
+			assert.Equal(CachedArtifact{ARTIFACT_MD5, legacy.MD5, int64(len(c.data)), artifacts[1].ModTime}, *artifacts[1], c.name)
+		}
+
+		// found by both the releases and the legacy releases without any download
+		for _, cs := range []*ArtifactChecksum{checksum, legacy} {
+			path, err := cache.Fetch(context.Background(), nil, cs, []string{key})
+			assert.NoError(err, c.name)
+			content, _ := ioutil.ReadFile(path)
+			assert.Equal(c.data, content, c.name)
+		}
+	}
+
+	listed, err := cache.List()
+	assert.NoError(err)
+	assert.Equal(4, len(listed))
+}
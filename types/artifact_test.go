@@ -0,0 +1,99 @@
+package types
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/ed25519"
+)
+
+// newPublisher returns a hex encoded publisher key, and a function signing the checksum of an artifact with it
+func newPublisher(t *testing.T) (string, func(data []byte) *ArtifactChecksum) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(pub[:]), func(data []byte) *ArtifactChecksum {
+		digest := sha256.Sum256(data)
+		sig := ed25519.Sign(priv, digest[:])
+		return &ArtifactChecksum{Sha256: hex.EncodeToString(digest[:]), Signature: hex.EncodeToString(sig[:])}
+	}
+}
+
+func TestArtifactChecksumVerifySignature(t *testing.T) {
+	assert := assert.New(t)
+
+	key, sign := newPublisher(t)
+	otherKey, _ := newPublisher(t)
+	checksum := sign([]byte("libeni"))
+	otherDigest := sha256.Sum256([]byte("other"))
+
+	cases := []struct {
+		name     string
+		checksum ArtifactChecksum
+		keys     []string
+		valid    bool
+	}{
+		{"signed", *checksum, []string{key}, true},
+		{"signed by one of the keys", *checksum, []string{"invalid", otherKey, key}, true},
+		{"0x prefixes", ArtifactChecksum{Sha256: "0x" + checksum.Sha256, Signature: "0x" + checksum.Signature}, []string{"0x" + key}, true},
+		{"signed by another key", *checksum, []string{otherKey}, false},
+		{"no publisher key", *checksum, nil, true},
+		{"not signed without publisher key", ArtifactChecksum{Sha256: checksum.Sha256}, nil, true},
+		{"invalid digest without publisher key", ArtifactChecksum{Sha256: checksum.Sha256[:62]}, nil, false},
+		{"other digest", ArtifactChecksum{Sha256: hex.EncodeToString(otherDigest[:]), Signature: checksum.Signature}, []string{key}, false},
+		{"invalid digest", ArtifactChecksum{Sha256: checksum.Sha256[:62], Signature: checksum.Signature}, []string{key}, false},
+		{"invalid signature", ArtifactChecksum{Sha256: checksum.Sha256, Signature: checksum.Signature[:126]}, []string{key}, false},
+		{"legacy", ArtifactChecksum{MD5: "d41d8cd98f00b204e9800998ecf8427e"}, []string{key}, false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(c.valid, c.checksum.VerifySignature(c.keys) == nil, c.name)
+	}
+}
+
+func TestArtifactChecksumVerifyFile(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "artifact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	data := []byte("libeni")
+	path := filepath.Join(dir, "libeni")
+	if err = ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	key, sign := newPublisher(t)
+	otherKey, _ := newPublisher(t)
+	md5Sum := md5.Sum(data)
+
+	cases := []struct {
+		name     string
+		checksum *ArtifactChecksum
+		path     string
+		keys     []string
+		valid    bool
+	}{
+		{"verified", sign(data), path, []string{key}, true},
+		{"other content", sign([]byte("other")), path, []string{key}, false},
+		{"not signed by the publisher", sign(data), path, []string{otherKey}, false},
+		{"missing file", sign(data), filepath.Join(dir, "missing"), []string{key}, false},
+		{"legacy", &ArtifactChecksum{MD5: hex.EncodeToString(md5Sum[:])}, path, nil, true},
+		{"legacy other content", &ArtifactChecksum{MD5: "d41d8cd98f00b204e9800998ecf8427e"}, path, nil, false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(c.valid, c.checksum.VerifyFile(c.path, c.keys) == nil, c.name)
+	}
+}
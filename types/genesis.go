@@ -72,6 +72,12 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		}
 	}
 
+	if genDoc.Params != nil {
+		if err := genDoc.Params.Validate(); err != nil {
+			return err
+		}
+	}
+
 	if len(genDoc.Validators) == 0 {
 		return errors.Errorf("The genesis file must have at least one validator")
 	}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"errors"
+	"strings"
 )
 
 // CmdInfo ...
@@ -10,6 +11,10 @@ type CmdInfo struct {
 	Version  string
 	DownloadURLs []string
 	MD5 string
+	// SHA-256 digest and its ed25519 signature, empty for legacy releases
+	Sha256        string
+	Signature     string
+	PublisherKeys []string
 }
 
 // Monitor ...
@@ -33,6 +38,9 @@ func (r *Monitor) Download(info *CmdInfo, reply *MonitorResponse) error {
 	if info == nil || info.Name == "" {
 		return errors.New("CmdInfo can't be nil")
 	}
+	if err := info.validate(); err != nil {
+		return err
+	}
 	reply.Code = 0
 	reply.Msg = []byte("Received download info successfully")
 	r.cmd.DownloadChan <- info
@@ -44,6 +52,9 @@ func (r *Monitor) Upgrade(info *CmdInfo, reply *MonitorResponse) error {
 	if info == nil || info.Name == "" {
 		return errors.New("CmdInfo can't be nil")
 	}
+	if err := info.validate(); err != nil {
+		return err
+	}
 	reply.Code = 0
 	reply.Msg = []byte("Received upgrade info successfully")
 	r.cmd.UpgradeChan <- info
@@ -62,3 +73,18 @@ func (r *Monitor) Kill(info *CmdInfo, reply *MonitorResponse) error {
 func (c *CmdInfo)ReleaseName() string {
 	return c.Name + "_" + c.Version
 }
+
+// validate checks the name and the version can't escape the bin directory the release is staged in
+func (c *CmdInfo) validate() error {
+	for _, s := range []string{c.Name, c.Version} {
+		if strings.ContainsAny(s, "/\\") || strings.Contains(s, "..") {
+			return errors.New("invalid release name or version: " + s)
+		}
+	}
+	return nil
+}
+
+// Checksum returns the checksum the release is verified against before the swap
+func (c *CmdInfo) Checksum() *ArtifactChecksum {
+	return &ArtifactChecksum{c.Sha256, c.Signature, c.MD5}
+}
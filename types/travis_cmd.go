@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -14,6 +15,18 @@ import (
 	"time"
 )
 
+const (
+	// link to the binary of the current version under the bin directory
+	currentLink = "current"
+	// how long the old version is given to exit before it is killed
+	stopTimeout = 30 * time.Second
+	// how long the old version is given to exit once killed, the upgrade is aborted if it is still running
+	killTimeout = 5 * time.Second
+)
+
+// how long a new version has to keep running to be considered started
+var startupGracePeriod = 10 * time.Second
+
 // TravisCmd ...
 type TravisCmd struct {
 	Root     string
@@ -32,13 +45,20 @@ type TravisCmd struct {
 	downloaded   bool          // donwload successfully
 	startTime    time.Time     // if started true
 	cmd          *exec.Cmd
+	exited       chan struct{} // closed once the process exits
+	// cache the releases are downloaded through, they are downloaded directly if nil
+	Artifacts *ArtifactCache
 }
 
-// NewTravisCmd create a new travis CMD
+// NewTravisCmd create a new travis CMD, the version switched to by the last upgrade is run if any
 func NewTravisCmd(root string, name string, arg ...string) *TravisCmd {
+	path := filepath.Join(root, "bin")
+	if current, err := os.Readlink(filepath.Join(path, currentLink)); err == nil {
+		name = current
+	}
 	return &TravisCmd{
 		Root:         root,
-		Path:         filepath.Join(root, "bin"),
+		Path:         path,
 		Name:         name,
 		Args:         arg,
 		Mutex:        &sync.Mutex{},
@@ -63,12 +83,14 @@ func (c *TravisCmd) Start() error {
 	stderr := io.MultiWriter(os.Stderr, &stderrBuf)
 	err := cmd.Start()
 	if err != nil {
-		log.Fatalf("cmd.Start() failed with '%s'\n", err)
+		return fmt.Errorf("cmd.Start() failed with '%s'", err)
 	}
+	exited := make(chan struct{})
 
 	c.started = true
 	c.startTime = time.Now()
 	c.cmd = cmd
+	c.exited = exited
 	c.downloaded = false
 	c.NextName = ""
 	c.NextArgs = nil
@@ -83,6 +105,7 @@ func (c *TravisCmd) Start() error {
 
 	go func() {
 		err = cmd.Wait()
+		close(exited)
 		if err != nil {
 			fmt.Printf("cmd.Run() failed with %s\n", err)
 		}
@@ -93,8 +116,11 @@ func (c *TravisCmd) Start() error {
 	return nil
 }
 
-// Stop the sub travis process
+// Stop the sub travis process, nothing is done if it is not running
 func (c *TravisCmd) Stop() error {
+	if c.cmd == nil {
+		return nil
+	}
 	pro, err := os.FindProcess(c.cmd.Process.Pid)
 	if err != nil {
 		fmt.Printf("can not find rpocess:%d\n", c.cmd.Process.Pid)
@@ -121,48 +147,149 @@ func (c *TravisCmd) Restart() error {
 	if err := c.Stop(); err != nil {
 		return err
 	}
-	c.Start()
-	return nil
+	return c.Start()
 }
 
-// Upgrade upgrade to new version travis
+// Upgrade upgrade to new version travis, the previous version is started again if the new one fails to start
 func (c *TravisCmd) Upgrade(cmdInfo *CmdInfo) error {
 	c.Lock()
 	defer c.Unlock()
 	// TODO: need sleep a while to wait something finish ?
 	time.Sleep(time.Second * 1)
 
-	// stop the old
-	if err := c.Stop(); err != nil {
+	// the new version is downloaded now if its download failed or was missed
+	if !c.downloaded || c.NextName != cmdInfo.ReleaseName() {
+		if err := c.download(cmdInfo); err != nil {
+			return err
+		}
+	}
+
+	// verify the new version before stopping the old
+	if err := cmdInfo.Checksum().VerifyFile(filepath.Join(c.Path, c.NextName), cmdInfo.PublisherKeys); err != nil {
 		return err
 	}
 
-	if !c.downloaded || c.NextName == "" {
-		return errors.New("no new version travis get ready")
+	// stop the old, and wait for it to release the data directory
+	cmd, exited := c.cmd, c.exited
+	if err := c.Stop(); err != nil {
+		return err
+	}
+	if err := waitExit(cmd, exited); err != nil {
+		return err
 	}
 
 	// using the new version
-	c.Name = c.NextName
+	previous := c.Name
+	if err := c.switchTo(c.NextName); err != nil {
+		if serr := c.Start(); serr != nil {
+			return fmt.Errorf("%s, and %s failed to start again: %s", err, c.Name, serr)
+		}
+		return &RolledBackError{err}
+	}
+	err := c.startAndWatch()
+	if err == nil {
+		return nil
+	}
+
+	log.Printf("%s failed to start: %s, rolling back to %s\n", c.Name, err, previous)
+	if rerr := c.switchTo(previous); rerr != nil {
+		return rerr
+	}
+	if rerr := c.Start(); rerr != nil {
+		return rerr
+	}
+	return &RolledBackError{err}
+}
+
+// RolledBackError is returned by Upgrade if the new version failed to start, and the previous version runs again
+type RolledBackError struct {
+	Err error
+}
+
+func (e *RolledBackError) Error() string {
+	return e.Err.Error() + ", the previous version is running again"
+}
+
+// waitExit waits for the stopped process to exit, it is killed if it is still running after stopTimeout.
+// An error is returned if it doesn't exit even then, the new version must not run against the same data directory.
+func waitExit(cmd *exec.Cmd, exited chan struct{}) error {
+	if cmd == nil {
+		return nil
+	}
+	select {
+	case <-exited:
+		return nil
+	case <-time.After(stopTimeout):
+	}
+
+	log.Printf("%s still running after %s, killing it\n", cmd.Path, stopTimeout)
+	if err := cmd.Process.Kill(); err != nil {
+		log.Printf("failed to kill %s: %s\n", cmd.Path, err)
+	}
+	select {
+	case <-exited:
+		return nil
+	case <-time.After(killTimeout):
+		return fmt.Errorf("%s is still running, the upgrade is aborted", cmd.Path)
+	}
+}
+
+// startAndWatch starts the process, and checks it keeps running during the startup grace period
+func (c *TravisCmd) startAndWatch() error {
+	if err := c.Start(); err != nil {
+		return err
+	}
+	select {
+	case <-c.exited:
+		c.started = false
+		c.cmd = nil
+		return errors.New("exited during startup")
+	case <-time.After(startupGracePeriod):
+		return nil
+	}
+}
 
-	c.Start()
+// switchTo makes the version current, the link under the bin directory is replaced atomically
+// so that the monitor runs the same version after a restart
+func (c *TravisCmd) switchTo(name string) error {
+	link := filepath.Join(c.Path, currentLink)
+	tmp := link + ".tmp"
+	os.Remove(tmp)
+	if err := os.Symlink(name, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	c.Name = name
 	return nil
 }
 
-// Download download the new version travis as specified
+// Download download the new version travis as specified,
+// it is verified and staged as <home>/bin/<name>_<version>
 func (c *TravisCmd) Download(cmdInfo *CmdInfo) error {
 	c.Lock()
 	defer c.Unlock()
+	return c.download(cmdInfo)
+}
 
+func (c *TravisCmd) download(cmdInfo *CmdInfo) error {
 	if c.downloaded && c.NextName == cmdInfo.ReleaseName() {
 		log.Println("same version already exist")
 		return nil
 	}
 
-	// TODO: automatically download comming soon ...
-	//if err := exec.Command("cp", "-f", filepath.Join(c.Path, "travis"), filepath.Join(c.Path, name)).Run(); err != nil {
-	//	return err
-	//}
-	log.Println("download does not happen automatically, please copy it manually")
+	staged := filepath.Join(c.Path, cmdInfo.ReleaseName())
+	// a binary staged before, e.g. copied manually, is kept if it is verified
+	if err := cmdInfo.Checksum().VerifyFile(staged, cmdInfo.PublisherKeys); err != nil {
+		if err := c.fetch(cmdInfo, staged); err != nil {
+			return err
+		}
+	}
+	if err := os.Chmod(staged, 0755); err != nil {
+		return err
+	}
 
 	// using the new version
 	c.NextName = cmdInfo.ReleaseName()
@@ -171,6 +298,24 @@ func (c *TravisCmd) Download(cmdInfo *CmdInfo) error {
 	return nil
 }
 
+// fetch downloads the release to the path through the artifact cache if any
+func (c *TravisCmd) fetch(cmdInfo *CmdInfo, path string) error {
+	if err := os.MkdirAll(c.Path, 0755); err != nil {
+		return err
+	}
+
+	checksum := cmdInfo.Checksum()
+	if c.Artifacts == nil {
+		return DownloadArtifact(context.Background(), cmdInfo.DownloadURLs, path, checksum, cmdInfo.PublisherKeys)
+	}
+	cached, err := c.Artifacts.Fetch(context.Background(), cmdInfo.DownloadURLs, checksum, cmdInfo.PublisherKeys)
+	if err != nil {
+		return err
+	}
+	// copied rather than linked, as the cache may be pruned
+	return DownloadArtifact(context.Background(), []string{"file://" + cached}, path, checksum, cmdInfo.PublisherKeys)
+}
+
 // Cmd ...
 func (c *TravisCmd) Cmd() *exec.Cmd {
 	return c.cmd
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTravisRoot returns a home directory with the scripts as versions under its bin directory
+func newTravisRoot(t *testing.T, scripts map[string]string) string {
+	root, err := ioutil.TempDir("", "travis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin := filepath.Join(root, "bin")
+	if err = os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, script := range scripts {
+		if err = ioutil.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func releaseOf(t *testing.T, root, name, version string) *CmdInfo {
+	md5, err := FileMd5(filepath.Join(root, "bin", name+"_"+version))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &CmdInfo{Name: name, Version: version, MD5: md5}
+}
+
+func currentVersion(t *testing.T, root string) string {
+	current, err := os.Readlink(filepath.Join(root, "bin", currentLink))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return current
+}
+
+func TestTravisCmdUpgrade(t *testing.T) {
+	defer func(d time.Duration) { startupGracePeriod = d }(startupGracePeriod)
+	startupGracePeriod = 500 * time.Millisecond
+
+	root := newTravisRoot(t, map[string]string{
+		"travis":        "exec sleep 60",
+		"travis_v2":     "exec sleep 60",
+		"travis_broken": "exit 1",
+	})
+	defer os.RemoveAll(root)
+
+	c := NewTravisCmd(root, "travis")
+	if err := c.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { c.Stop() }()
+
+	if err := c.Upgrade(releaseOf(t, root, "travis", "v2")); err != nil {
+		t.Fatalf("upgrade: %v", err)
+	}
+	if c.Name != "travis_v2" || currentVersion(t, root) != "travis_v2" {
+		t.Fatalf("running %s, current link %s, want travis_v2", c.Name, currentVersion(t, root))
+	}
+
+	// the monitor restarted after the upgrade runs the switched version
+	if name := NewTravisCmd(root, "travis").Name; name != "travis_v2" {
+		t.Errorf("restarted monitor runs %s, want travis_v2", name)
+	}
+}
+
+func TestTravisCmdUpgradeRollback(t *testing.T) {
+	defer func(d time.Duration) { startupGracePeriod = d }(startupGracePeriod)
+	startupGracePeriod = 500 * time.Millisecond
+
+	root := newTravisRoot(t, map[string]string{
+		"travis":        "exec sleep 60",
+		"travis_broken": "exit 1",
+	})
+	defer os.RemoveAll(root)
+
+	c := NewTravisCmd(root, "travis")
+	if err := c.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { c.Stop() }()
+
+	err := c.Upgrade(releaseOf(t, root, "travis", "broken"))
+	if _, ok := err.(*RolledBackError); !ok {
+		t.Fatalf("upgrade to a version exiting at startup returned %v, want a RolledBackError", err)
+	}
+	if c.Name != "travis" || currentVersion(t, root) != "travis" {
+		t.Errorf("running %s, current link %s, want travis", c.Name, currentVersion(t, root))
+	}
+	if c.Cmd() == nil {
+		t.Error("the previous version should be running again")
+	}
+}
+
+func TestTravisCmdUpgradeUnverified(t *testing.T) {
+	root := newTravisRoot(t, map[string]string{
+		"travis":    "exec sleep 60",
+		"travis_v2": "exec sleep 60",
+	})
+	defer os.RemoveAll(root)
+
+	c := NewTravisCmd(root, "travis")
+	if err := c.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { c.Stop() }()
+	running := c.Cmd()
+
+	// the staged binary doesn't match the release, and it can't be downloaded again
+	info := &CmdInfo{Name: "travis", Version: "v2", MD5: "00000000000000000000000000000000"}
+	if err := c.Upgrade(info); err == nil {
+		t.Fatal("upgrade to an unverified release should fail")
+	}
+	if c.Cmd() != running || c.Name != "travis" {
+		t.Error("the old version should be left running")
+	}
+	if _, err := os.Readlink(filepath.Join(root, "bin", currentLink)); !os.IsNotExist(err) {
+		t.Errorf("no version should be switched to, got %v", err)
+	}
+}
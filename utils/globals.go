@@ -32,6 +32,26 @@ const (
 	MonitorRpcPort = "26650"
 )
 
+// governanceUpgradeHeights are the block heights from which the networks launched before the governance
+// upgrade hash the state it added, the other networks hash it from the genesis
+var governanceUpgradeHeights = map[uint]int64{
+	Staging: math.MaxInt64,
+	TestNet: math.MaxInt64,
+	MainNet: math.MaxInt64,
+}
+
+var governanceUpgradeHeight int64
+
+// SetGovernanceUpgradeHeight selects the governance upgrade height of the network
+func SetGovernanceUpgradeHeight(networkId uint) {
+	governanceUpgradeHeight = governanceUpgradeHeights[networkId]
+}
+
+// IsGovernanceUpgraded tells whether the block at the given height is past the governance upgrade
+func IsGovernanceUpgraded(height int64) bool {
+	return height >= governanceUpgradeHeight
+}
+
 type StateChangeObject struct {
 	From   common.Address
 	To     common.Address
@@ -211,4 +231,6 @@ var (
 	MintAccount    = common.HexToAddress("0000000000000000000000000000000000000000")
 	HoldAccount    = common.HexToAddress("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF")
 	GovHoldAccount = common.HexToAddress("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF")
+	// sender of the contract calls made by the approved contract_call proposals, it is funded by transfers like any account
+	GovAccount = common.HexToAddress("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFE")
 )
@@ -1,15 +1,20 @@
 package utils
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/vangjvn/devchain/sdk"
 )
 
 type Params struct {
-	ProposalExpirePeriod                   uint64 `json:"proposal_expire_period" type:"uint"`
+	ProposalExpirePeriod                   uint64 `json:"proposal_expire_period" type:"uint" min:"1"`
 	DeclareCandidacyGas                    uint64 `json:"declare_candidacy_gas" type:"uint"`
 	UpdateCandidacyGas                     uint64 `json:"update_candidacy_gas" type:"uint"`
 	UpdateCandidateAccountGas              uint64 `json:"update_candidate_account_gas" type:"uint"`
@@ -19,10 +24,70 @@ type Params struct {
 	DeployLibEniProposalGas                uint64 `json:"deploy_libeni_proposal_gas" type:"uint"`
 	RetireProgramProposalGas               uint64 `json:"retire_program_proposal_gas" type:"uint"`
 	UpgradeProgramProposalGas              uint64 `json:"upgrade_program_proposal_gas" type:"uint"`
+	TextProposalGas                        uint64 `json:"text_proposal_gas" type:"uint"`
+	AddValidatorProposalGas                uint64 `json:"add_validator_proposal_gas" type:"uint"`
+	RemoveValidatorProposalGas             uint64 `json:"remove_validator_proposal_gas" type:"uint"`
+	GrantProposalGas                       uint64 `json:"grant_proposal_gas" type:"uint"`
+	CancelGrantProposalGas                 uint64 `json:"cancel_grant_proposal_gas" type:"uint"`
+	ContractCallProposalGas                uint64 `json:"contract_call_proposal_gas" type:"uint"`
+	EmergencyPauseProposalGas              uint64 `json:"emergency_pause_proposal_gas" type:"uint"`
+	UnpauseProposalGas                     uint64 `json:"unpause_proposal_gas" type:"uint"`
+	ConsensusParamsProposalGas             uint64 `json:"consensus_params_proposal_gas" type:"uint"`
 	GasPrice                               uint64 `json:"gas_price" type:"uint"`
-	LowPriceTxGasLimit                     uint64 `json:"low_price_tx_gas_limit" type:"uint"`
-	LowPriceTxSlotsCap                     int    `json:"low_price_tx_slots_cap" type:"int"`
-	FoundationAddress                      string `json:"foundation_address" type:"string"`
+	LowPriceTxGasLimit                     uint64 `json:"low_price_tx_gas_limit" type:"uint" min:"21000"`
+	LowPriceTxSlotsCap                     int    `json:"low_price_tx_slots_cap" type:"int" min:"0"`
+	// comma separated addresses of the foundation committee members, who sign off the verification of candidates
+	FoundationCommittee string `json:"foundation_committee" type:"string" format:"addresses"`
+	// number of distinct committee members whose sign off is required for a verification to take effect
+	FoundationCommitteeThreshold int `json:"foundation_committee_threshold" type:"int" min:"1"`
+	// quorum is the share of the total voting power which has to vote,
+	// threshold is the share of the total voting power, or of the votes cast, which has to be reached by the yes votes
+	TransferFundProposalQuorum       sdk.Rat `json:"transfer_fund_proposal_quorum" type:"rat" min:"0" max:"1"`
+	TransferFundProposalThreshold    sdk.Rat `json:"transfer_fund_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	ChangeParamsProposalQuorum       sdk.Rat `json:"change_params_proposal_quorum" type:"rat" min:"0" max:"1"`
+	ChangeParamsProposalThreshold    sdk.Rat `json:"change_params_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	DeployLibEniProposalQuorum       sdk.Rat `json:"deploy_libeni_proposal_quorum" type:"rat" min:"0" max:"1"`
+	DeployLibEniProposalThreshold    sdk.Rat `json:"deploy_libeni_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	RetireProgramProposalQuorum      sdk.Rat `json:"retire_program_proposal_quorum" type:"rat" min:"0" max:"1"`
+	RetireProgramProposalThreshold   sdk.Rat `json:"retire_program_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	UpgradeProgramProposalQuorum     sdk.Rat `json:"upgrade_program_proposal_quorum" type:"rat" min:"0" max:"1"`
+	UpgradeProgramProposalThreshold  sdk.Rat `json:"upgrade_program_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	TextProposalQuorum               sdk.Rat `json:"text_proposal_quorum" type:"rat" min:"0" max:"1"`
+	TextProposalThreshold            sdk.Rat `json:"text_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	AddValidatorProposalQuorum       sdk.Rat `json:"add_validator_proposal_quorum" type:"rat" min:"0" max:"1"`
+	AddValidatorProposalThreshold    sdk.Rat `json:"add_validator_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	RemoveValidatorProposalQuorum    sdk.Rat `json:"remove_validator_proposal_quorum" type:"rat" min:"0" max:"1"`
+	RemoveValidatorProposalThreshold sdk.Rat `json:"remove_validator_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	GrantProposalQuorum              sdk.Rat `json:"grant_proposal_quorum" type:"rat" min:"0" max:"1"`
+	GrantProposalThreshold           sdk.Rat `json:"grant_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	CancelGrantProposalQuorum        sdk.Rat `json:"cancel_grant_proposal_quorum" type:"rat" min:"0" max:"1"`
+	CancelGrantProposalThreshold     sdk.Rat `json:"cancel_grant_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	ContractCallProposalQuorum       sdk.Rat `json:"contract_call_proposal_quorum" type:"rat" min:"0" max:"1"`
+	ContractCallProposalThreshold    sdk.Rat `json:"contract_call_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	EmergencyPauseProposalQuorum     sdk.Rat `json:"emergency_pause_proposal_quorum" type:"rat" min:"0" max:"1"`
+	EmergencyPauseProposalThreshold  sdk.Rat `json:"emergency_pause_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	UnpauseProposalQuorum            sdk.Rat `json:"unpause_proposal_quorum" type:"rat" min:"0" max:"1"`
+	UnpauseProposalThreshold         sdk.Rat `json:"unpause_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	ConsensusParamsProposalQuorum    sdk.Rat `json:"consensus_params_proposal_quorum" type:"rat" min:"0" max:"1"`
+	ConsensusParamsProposalThreshold sdk.Rat `json:"consensus_params_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	// when set, the thresholds are shares of the total voting power and the proposals are rejected once the no votes reach them,
+	// otherwise they are shares of the votes cast, the abstentions left out, and the vetoes apply
+	ProposalThresholdOfTotalPower bool `json:"proposal_threshold_of_total_power" type:"bool"`
+	// the proposal is rejected once the no with veto votes exceed this share of the votes cast
+	ProposalVetoThreshold sdk.Rat `json:"proposal_veto_threshold" type:"rat" min:"0" max:"1"`
+	// amount of CMTs in wei escrowed from the proposer, which is burned if the proposal is rejected
+	MinProposalDeposit string `json:"min_proposal_deposit" type:"bigint" min:"0"`
+	// number of blocks an approved transfer_fund, change_param, contract_call or consensus_params proposal is queued before its execution
+	ProposalExecutionDelay uint64 `json:"proposal_execution_delay" type:"uint"`
+	// candidates are promoted to validators only after an add_validator proposal is approved
+	ValidatorAdmissionRequired bool `json:"validator_admission_required" type:"bool"`
+	// gas limit of the call made by an approved contract_call proposal
+	ContractCallProposalMaxGas uint64 `json:"contract_call_proposal_max_gas" type:"uint"`
+	// number of blocks an emergency pause lasts unless it is lifted earlier, also the longest pause a proposal can ask for
+	EmergencyPauseDuration uint64 `json:"emergency_pause_duration" type:"uint" min:"1"`
+	// comma separated hex encoded ed25519 public keys, one of which has to sign the SHA-256 digest of a library or program release,
+	// the releases are not checked against any signature while it is empty
+	OtaPublisherKeys string `json:"ota_publisher_keys" type:"string" format:"ed25519_keys"`
 }
 
 func DefaultParams() *Params {
@@ -37,10 +102,56 @@ func DefaultParams() *Params {
 		RetireProgramProposalGas:               2e6,
 		UpgradeProgramProposalGas:              2e6,
 		DeployLibEniProposalGas:                2e6,
+		TextProposalGas:                        2e6,
+		AddValidatorProposalGas:                2e6,
+		RemoveValidatorProposalGas:             2e6,
+		GrantProposalGas:                       2e6,
+		CancelGrantProposalGas:                 2e6,
+		ContractCallProposalGas:                2e6,
+		EmergencyPauseProposalGas:              2e6,
+		UnpauseProposalGas:                     2e6,
+		ConsensusParamsProposalGas:             2e6,
 		GasPrice:                               0,
 		LowPriceTxGasLimit:                     9223372036854775807, // Maximum gas limit for low-price transaction
 		LowPriceTxSlotsCap:                     2147483647,          // Maximum number of low-price transaction slots per block
-		FoundationAddress:                      "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc",
+		FoundationCommittee:                    "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc",
+		FoundationCommitteeThreshold:           1,
+		TransferFundProposalQuorum:             sdk.NewRat(2, 3),
+		TransferFundProposalThreshold:          sdk.NewRat(2, 3),
+		ChangeParamsProposalQuorum:             sdk.NewRat(2, 3),
+		ChangeParamsProposalThreshold:          sdk.NewRat(2, 3),
+		DeployLibEniProposalQuorum:             sdk.NewRat(2, 3),
+		DeployLibEniProposalThreshold:          sdk.NewRat(2, 3),
+		RetireProgramProposalQuorum:            sdk.NewRat(2, 3),
+		RetireProgramProposalThreshold:         sdk.NewRat(2, 3),
+		UpgradeProgramProposalQuorum:           sdk.NewRat(2, 3),
+		UpgradeProgramProposalThreshold:        sdk.NewRat(2, 3),
+		TextProposalQuorum:                     sdk.NewRat(2, 3),
+		TextProposalThreshold:                  sdk.NewRat(2, 3),
+		AddValidatorProposalQuorum:             sdk.NewRat(2, 3),
+		AddValidatorProposalThreshold:          sdk.NewRat(2, 3),
+		RemoveValidatorProposalQuorum:          sdk.NewRat(2, 3),
+		RemoveValidatorProposalThreshold:       sdk.NewRat(2, 3),
+		GrantProposalQuorum:                    sdk.NewRat(2, 3),
+		GrantProposalThreshold:                 sdk.NewRat(2, 3),
+		CancelGrantProposalQuorum:              sdk.NewRat(2, 3),
+		CancelGrantProposalThreshold:           sdk.NewRat(2, 3),
+		ContractCallProposalQuorum:             sdk.NewRat(2, 3),
+		ContractCallProposalThreshold:          sdk.NewRat(2, 3),
+		EmergencyPauseProposalQuorum:           sdk.NewRat(2, 3),
+		EmergencyPauseProposalThreshold:        sdk.NewRat(2, 3),
+		UnpauseProposalQuorum:                  sdk.NewRat(2, 3),
+		UnpauseProposalThreshold:               sdk.NewRat(2, 3),
+		ConsensusParamsProposalQuorum:          sdk.NewRat(2, 3),
+		ConsensusParamsProposalThreshold:       sdk.NewRat(2, 3),
+		ProposalThresholdOfTotalPower:          true, // yes votes of 2/3 of the voting power, as before the quorums
+		ProposalVetoThreshold:                  sdk.NewRat(1, 3),
+		MinProposalDeposit:                     "1000000000000000000000", // 1000 CMTs
+		ProposalExecutionDelay:                 0,
+		ValidatorAdmissionRequired:             false,
+		ContractCallProposalMaxGas:             8e6,
+		EmergencyPauseDuration:                 24 * 3600 / uint64(CommitSeconds),
+		OtaPublisherKeys:                       "",
 	}
 }
 
@@ -54,6 +165,36 @@ var (
 	params              = new(Params)
 )
 
+// UnmarshalJSON decodes the params over the default params, so that the params missing from the json,
+// i.e. introduced after the params were stored or the genesis file was written, take their default values
+func (p *Params) UnmarshalJSON(b []byte) error {
+	type rawParams Params // without the UnmarshalJSON method
+	rp := rawParams(*DefaultParams())
+	if err := json.Unmarshal(b, &rp); err != nil {
+		return err
+	}
+	*p = Params(rp)
+	return p.migrate(b)
+}
+
+// migrate carries over the params which have been renamed since the params were stored
+func (p *Params) migrate(b []byte) error {
+	var stored map[string]json.RawMessage
+	if err := json.Unmarshal(b, &stored); err != nil {
+		return err
+	}
+
+	// foundation_address has been replaced by the foundation committee, the foundation is its only member
+	if committee, ok := stored["foundation_committee"]; !ok || string(committee) == `""` {
+		var address string
+		if json.Unmarshal(stored["foundation_address"], &address) == nil && address != "" {
+			p.FoundationCommittee = address
+			p.FoundationCommitteeThreshold = 1
+		}
+	}
+	return nil
+}
+
 // load/save the global params
 func LoadParams(b []byte) {
 	json.Unmarshal(b, params)
@@ -79,7 +220,16 @@ func CleanParams() (before bool) {
 }
 
 func SetParam(name, value string) bool {
-	pv := reflect.ValueOf(params).Elem()
+	if !params.Set(name, value) {
+		return false
+	}
+	dirty = true
+	return true
+}
+
+// Set sets the param of the given name, it returns false if there is no such param
+func (p *Params) Set(name, value string) bool {
+	pv := reflect.ValueOf(p).Elem()
 	top := pv.Type()
 	for i := 0; i < pv.NumField(); i++ {
 		fv := pv.Field(i)
@@ -108,7 +258,6 @@ func SetParam(name, value string) bool {
 					}
 				}
 			}
-			dirty = true
 			return true
 		}
 	}
@@ -116,50 +265,174 @@ func SetParam(name, value string) bool {
 	return false
 }
 
+// CheckParamType checks the value can be parsed as the type of the param,
+// and satisfies the constraints of its min, max and format tags
 func CheckParamType(name, value string) bool {
 	pv := reflect.ValueOf(params).Elem()
 	top := pv.Type()
 	for i := 0; i < pv.NumField(); i++ {
-		if top.Field(i).Tag.Get("json") == name {
-			switch top.Field(i).Tag.Get("type") {
+		field := top.Field(i)
+		if field.Tag.Get("json") == name {
+			// numeric values are checked against the min and max tags
+			var num *big.Rat
+			switch field.Tag.Get("type") {
 			case "bool":
-				if _, err := strconv.ParseBool(value); err == nil {
-					return true
+				if _, err := strconv.ParseBool(value); err != nil {
+					return false
 				}
 			case "int":
-				if _, err := strconv.ParseInt(value, 10, 64); err == nil {
-					return true
+				iv, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return false
 				}
+				num = new(big.Rat).SetInt64(iv)
 			case "uint":
-				if _, err := strconv.ParseUint(value, 10, 64); err == nil {
-					return true
+				iv, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					return false
 				}
+				num = new(big.Rat).SetInt(new(big.Int).SetUint64(iv))
 			case "float":
-				if iv, err := strconv.ParseFloat(value, 64); err == nil {
-					if iv > 0 {
-						return true
-					}
+				iv, err := strconv.ParseFloat(value, 64)
+				if err != nil || iv <= 0 {
+					return false
+				}
+				if num = new(big.Rat).SetFloat64(iv); num == nil {
+					return false
 				}
 			case "json":
 				var s map[string]interface{}
-				if err := json.Unmarshal([]byte(value), &s); err == nil {
-					return true
-				}
 				var b []interface{}
-				if err := json.Unmarshal([]byte(value), &b); err == nil {
-					return true
+				if json.Unmarshal([]byte(value), &s) != nil && json.Unmarshal([]byte(value), &b) != nil {
+					return false
+				}
+			case "bigint":
+				iv, ok := new(big.Int).SetString(value, 10)
+				if !ok || iv.Sign() < 0 {
+					return false
 				}
+				num = new(big.Rat).SetInt(iv)
 			case "string":
-				return true
 			case "rat":
 				v := sdk.NewRat(0, 1)
-				if err := json.Unmarshal([]byte("\""+value+"\""), &v); err == nil {
-					return true
+				if err := json.Unmarshal([]byte("\""+value+"\""), &v); err != nil {
+					return false
 				}
+				num = v.Rat
+			default:
+				return false
 			}
+			return checkParamConstraints(field, value, num)
+		}
+	}
+
+	return false
+}
+
+func checkParamConstraints(field reflect.StructField, value string, num *big.Rat) bool {
+	if num != nil {
+		if min, ok := new(big.Rat).SetString(field.Tag.Get("min")); ok && num.Cmp(min) < 0 {
 			return false
 		}
+		if max, ok := new(big.Rat).SetString(field.Tag.Get("max")); ok && num.Cmp(max) > 0 {
+			return false
+		}
+	}
+
+	switch field.Tag.Get("format") {
+	case "address":
+		return common.IsHexAddress(value)
+	case "addresses":
+		return parseAddresses(value) != nil
+	case "ed25519_keys":
+		_, ok := parseEd25519Keys(value)
+		return ok
+	}
+	return true
+}
+
+// parseAddresses parses a comma separated list of distinct addresses, nil is returned if any is invalid
+func parseAddresses(value string) (addrs []common.Address) {
+	seen := make(map[common.Address]bool)
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if !common.IsHexAddress(s) {
+			return nil
+		}
+		addr := common.HexToAddress(s)
+		if seen[addr] {
+			return nil
+		}
+		seen[addr] = true
+		addrs = append(addrs, addr)
+	}
+	return
+}
+
+// parseEd25519Keys parses a comma separated list of hex encoded ed25519 public keys, which may be empty
+func parseEd25519Keys(value string) (keys []string, ok bool) {
+	if strings.TrimSpace(value) == "" {
+		return nil, true
+	}
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimPrefix(strings.TrimSpace(s), "0x")
+		if b, err := hex.DecodeString(s); err != nil || len(b) != 32 {
+			return nil, false
+		}
+		keys = append(keys, strings.ToLower(s))
 	}
+	return keys, true
+}
+
+// OtaPublisherKeyList returns the publisher keys of the library and program releases
+func (p *Params) OtaPublisherKeyList() []string {
+	keys, _ := parseEd25519Keys(p.OtaPublisherKeys)
+	return keys
+}
 
+// FoundationCommitteeMembers returns the addresses of the foundation committee
+func (p *Params) FoundationCommitteeMembers() []common.Address {
+	return parseAddresses(p.FoundationCommittee)
+}
+
+// IsFoundationCommitteeMember checks whether the address is one of the foundation committee
+func (p *Params) IsFoundationCommitteeMember(addr common.Address) bool {
+	for _, m := range p.FoundationCommitteeMembers() {
+		if m == addr {
+			return true
+		}
+	}
 	return false
 }
+
+// Validate checks the constraints between params, which can't be expressed by the tags of a single param
+func (p *Params) Validate() error {
+	if p.FoundationCommitteeThreshold > len(p.FoundationCommitteeMembers()) {
+		return fmt.Errorf("foundation_committee_threshold exceeds the number of the foundation committee members")
+	}
+	return nil
+}
+
+// ParamSchema describes the type and the constraints of a param
+type ParamSchema struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Min    string `json:"min,omitempty"`
+	Max    string `json:"max,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+func GetParamSchema() (schema []ParamSchema) {
+	top := reflect.TypeOf(Params{})
+	for i := 0; i < top.NumField(); i++ {
+		field := top.Field(i)
+		schema = append(schema, ParamSchema{
+			Name:   field.Tag.Get("json"),
+			Type:   field.Tag.Get("type"),
+			Min:    field.Tag.Get("min"),
+			Max:    field.Tag.Get("max"),
+			Format: field.Tag.Get("format"),
+		})
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckParamType(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b := "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc", "0x84bf1d4b5b53c1b7e2d4b4bfa2e0f8f1c4fcd7a3"
+	key := strings.Repeat("ab", 32)
+
+	cases := []struct {
+		name, value string
+		expected    bool
+	}{
+		{"proposal_expire_period", "1", true},
+		{"proposal_expire_period", "0", false},
+		{"proposal_expire_period", "-1", false},
+		{"proposal_expire_period", "abc", false},
+		{"low_price_tx_gas_limit", "21000", true},
+		{"low_price_tx_gas_limit", "20999", false},
+		{"low_price_tx_slots_cap", "0", true},
+		{"low_price_tx_slots_cap", "-1", false},
+		{"foundation_committee_threshold", "1", true},
+		{"foundation_committee_threshold", "0", false},
+		{"transfer_fund_proposal_threshold", "1/2", true},
+		{"transfer_fund_proposal_threshold", "0.75", true},
+		{"transfer_fund_proposal_threshold", "1", true},
+		{"transfer_fund_proposal_threshold", "2/5", false},
+		{"transfer_fund_proposal_threshold", "3/2", false},
+		{"transfer_fund_proposal_threshold", "half", false},
+		{"change_params_proposal_quorum", "0", true},
+		{"change_params_proposal_quorum", "11/10", false},
+		{"proposal_veto_threshold", "-1/3", false},
+		{"min_proposal_deposit", "0", true},
+		{"min_proposal_deposit", "1000000000000000000000", true},
+		{"min_proposal_deposit", "-1", false},
+		{"min_proposal_deposit", "1e3", false},
+		{"validator_admission_required", "true", true},
+		{"validator_admission_required", "yes", false},
+		{"foundation_committee", a, true},
+		{"foundation_committee", a + ", " + b, true},
+		{"foundation_committee", a + "," + a, false},
+		{"foundation_committee", "0x1", false},
+		{"ota_publisher_keys", "", true},
+		{"ota_publisher_keys", key + ",0x" + key, true},
+		{"ota_publisher_keys", "abcd", false},
+		{"unknown_param", "1", false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(c.expected, CheckParamType(c.name, c.value), c.name+"="+c.value)
+	}
+}
+
+func TestParamsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b := "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc", "0x84bf1d4b5b53c1b7e2d4b4bfa2e0f8f1c4fcd7a3"
+
+	cases := []struct {
+		name      string
+		committee string
+		threshold int
+		valid     bool
+	}{
+		{"single member", a, 1, true},
+		{"threshold exceeds the members", a, 2, false},
+		{"all members", a + "," + b, 2, true},
+		{"invalid committee", "0x1", 1, false},
+	}
+
+	for _, c := range cases {
+		p := DefaultParams()
+		p.FoundationCommittee = c.committee
+		p.FoundationCommitteeThreshold = c.threshold
+		assert.Equal(c.valid, p.Validate() == nil, c.name)
+	}
+}
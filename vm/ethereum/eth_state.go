@@ -17,10 +17,8 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	abciTypes "github.com/tendermint/tendermint/abci/types"
 
-	"github.com/vangjvn/devchain/commons"
 	"github.com/vangjvn/devchain/errors"
 	gov "github.com/vangjvn/devchain/modules/governance"
-	"github.com/vangjvn/devchain/sdk"
 	"github.com/vangjvn/devchain/utils"
 	emtTypes "github.com/vangjvn/devchain/vm/types"
 )
@@ -236,97 +234,8 @@ func (ws *workState) deliverTx(blockchain *core.BlockChain, config *eth.Config,
 func (ws *workState) commit(blockchain *core.BlockChain, db ethdb.Database, receiver common.Address) (common.Hash, error) {
 	currentHeight := ws.header.Number.Int64()
 
-	proposalIds := utils.PendingProposal.ReachMin(int64(ws.parent.Time()), currentHeight)
-	for _, pid := range proposalIds {
-		proposal := gov.GetProposalById(pid)
-
-		switch proposal.Type {
-		case gov.TRANSFER_FUND_PROPOSAL:
-			amount, _ := sdk.NewIntFromString(proposal.Detail["amount"].(string))
-			switch gov.CheckProposal(pid, nil) {
-			case "approved":
-				commons.TransferWithReactor(utils.GovHoldAccount, *proposal.Detail["to"].(*common.Address), amount, gov.ProposalReactor{proposal.Id, currentHeight, "Approved"})
-			case "rejected":
-				commons.TransferWithReactor(utils.GovHoldAccount, *proposal.Detail["from"].(*common.Address), amount, gov.ProposalReactor{proposal.Id, currentHeight, "Rejected"})
-			default:
-				commons.TransferWithReactor(utils.GovHoldAccount, *proposal.Detail["from"].(*common.Address), amount, gov.ProposalReactor{proposal.Id, currentHeight, "Expired"})
-			}
-		case gov.CHANGE_PARAM_PROPOSAL:
-			switch gov.CheckProposal(pid, nil) {
-			case "approved":
-				utils.SetParam(proposal.Detail["name"].(string), proposal.Detail["value"].(string))
-				gov.ProposalReactor{proposal.Id, currentHeight, "Approved"}.React("success", "")
-			case "rejected":
-				gov.ProposalReactor{proposal.Id, currentHeight, "Rejected"}.React("success", "")
-			default:
-				gov.ProposalReactor{proposal.Id, currentHeight, "Expired"}.React("success", "")
-			}
-		case gov.DEPLOY_LIBENI_PROPOSAL:
-			if proposal.Result == "Approved" {
-				if proposal.Detail["status"] != "ready" {
-					gov.CancelDownload(proposal, true)
-				} else {
-					gov.RegisterLibEni(proposal)
-					gov.UpdateDeployLibEniStatus(proposal.Id, "deployed")
-				}
-			} else {
-				switch gov.CheckProposal(pid, nil) {
-				case "approved":
-					if proposal.Detail["status"] != "ready" {
-						gov.CancelDownload(proposal, true)
-					} else {
-						gov.RegisterLibEni(proposal)
-						gov.UpdateDeployLibEniStatus(proposal.Id, "deployed")
-					}
-					gov.ProposalReactor{proposal.Id, currentHeight, "Approved"}.React("success", "")
-				case "rejected":
-					if proposal.Detail["status"] != "ready" {
-						gov.CancelDownload(proposal, false)
-					}
-					gov.ProposalReactor{proposal.Id, currentHeight, "Rejected"}.React("success", "")
-				default:
-					if proposal.Detail["status"] != "ready" {
-						gov.CancelDownload(proposal, false)
-					}
-					gov.ProposalReactor{proposal.Id, currentHeight, "Expired"}.React("success", "")
-				}
-			}
-		case gov.RETIRE_PROGRAM_PROPOSAL:
-			if proposal.Result == "Approved" {
-				// process will be killed at next block
-				utils.RetiringProposalId = pid
-			} else {
-				switch gov.CheckProposal(pid, nil) {
-				case "approved":
-					// process will be killed at next block
-					utils.RetiringProposalId = pid
-					gov.ProposalReactor{proposal.Id, currentHeight, "Approved"}.React("success", "")
-				case "rejected":
-					gov.ProposalReactor{proposal.Id, currentHeight, "Rejected"}.React("success", "")
-				default:
-					gov.ProposalReactor{proposal.Id, currentHeight, "Expired"}.React("success", "")
-				}
-			}
-		case gov.UPGRADE_PROGRAM_PROPOSAL:
-			if proposal.Result == "Approved" {
-				// Upgrade program command to new version
-				gov.UpgradeProgramCmd(proposal)
-			} else {
-				switch gov.CheckProposal(pid, nil) {
-				case "approved":
-					// Upgrade program command to new version
-					gov.UpgradeProgramCmd(proposal)
-					gov.ProposalReactor{proposal.Id, currentHeight, "Approved"}.React("success", "")
-				case "rejected":
-					gov.ProposalReactor{proposal.Id, currentHeight, "Rejected"}.React("success", "")
-				default:
-					gov.ProposalReactor{proposal.Id, currentHeight, "Expired"}.React("success", "")
-				}
-			}
-		}
-
-		utils.PendingProposal.Del(pid)
-	}
+	// proposals reaching their expiration are resolved, queued ones are executed once their timelock has passed
+	gov.ProcessPendingProposals(ws.state, &govCaller{ws, blockchain}, int64(ws.parent.Time()), currentHeight)
 
 	ws.handleStateChangeQueue()
 
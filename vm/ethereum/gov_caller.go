@@ -0,0 +1,29 @@
+package ethereum
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// govCaller executes the message calls of the approved governance proposals
+// against the state of the block being committed
+type govCaller struct {
+	ws         *workState
+	blockchain *core.BlockChain
+}
+
+// Call runs the message call without gas price and nonce check, the state changes are reverted by the EVM if it fails
+func (gc *govCaller) Call(from, to common.Address, value *big.Int, data []byte, gas uint64, thash common.Hash) ([]byte, []*ethTypes.Log, uint64, error) {
+	ws := gc.ws
+	msg := ethTypes.NewMessage(from, &to, ws.state.GetNonce(from), value, gas, big.NewInt(0), data, false)
+	context := core.NewEVMContext(msg, ws.header, gc.blockchain, nil)
+	evm := vm.NewEVM(context, ws.state, gc.blockchain.Config(), *gc.blockchain.GetVMConfig())
+
+	ws.state.Prepare(thash, common.Hash{}, ws.txIndex)
+	ret, leftOverGas, err := evm.Call(vm.AccountRef(from), to, data, gas, value)
+	return ret, ws.state.GetLogs(thash), gas - leftOverGas, err
+}